@@ -0,0 +1,399 @@
+// Command httpsign signs and verifies HTTP request signatures produced by
+// [dev.gaijin.team/go/golib/http/sign] package, and decodes signatures for
+// inspection.
+//
+// Usage:
+//
+//	httpsign sign -key private.pem -method POST -url https://example.com/path -body body.json
+//	httpsign verify -key public.pem -method POST -url https://example.com/path -body body.json -signature 4...
+//	httpsign inspect 4...
+//
+// Keys are read from PEM files: PKCS #1, PKCS #8 and SEC 1 private keys, PKIX
+// and PKCS #1 public keys of RSA, Ed25519 and ECDSA P-256 algorithms. Run
+// a command with -h flag to list its options.
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"dev.gaijin.team/go/golib/http/sign"
+)
+
+const usage = `usage: httpsign <command> [options]
+
+commands:
+  sign     sign the request and print the signature
+  verify   verify the signature of the request
+  inspect  decode the signature and print its parts
+`
+
+var (
+	errUsage          = errors.New("invalid usage")
+	errNoPEMBlock     = errors.New("no PEM block found")
+	errUnsupportedKey = errors.New("unsupported key type")
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			_, _ = fmt.Fprintln(os.Stderr, "httpsign:", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+
+		return errUsage
+	}
+
+	switch args[0] {
+	case "sign":
+		return runSign(args[1:], stdin, stdout, stderr)
+	case "verify":
+		return runVerify(args[1:], stdin, stdout, stderr)
+	case "inspect":
+		return runInspect(args[1:], stdout, stderr)
+	default:
+		_, _ = fmt.Fprint(stderr, usage)
+
+		return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+	}
+}
+
+// requestFlags describe the request to sign or verify.
+type requestFlags struct {
+	method  string
+	url     string
+	body    string
+	headers headerFlags
+}
+
+func (rf *requestFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&rf.method, "method", http.MethodGet, "request method")
+	fs.StringVar(&rf.url, "url", "", "request URL")
+	fs.StringVar(&rf.body, "body", "", "file containing request body, - for stdin")
+	fs.Var(&rf.headers, "H", `request header in "Name: value" form, can be repeated`)
+}
+
+func (rf *requestFlags) request(stdin io.Reader) (*http.Request, error) {
+	if rf.url == "" {
+		return nil, fmt.Errorf("%w: -url is required", errUsage)
+	}
+
+	var (
+		body []byte
+		err  error
+	)
+
+	switch rf.body {
+	case "":
+	case "-":
+		body, err = io.ReadAll(stdin)
+	default:
+		body, err = os.ReadFile(rf.body)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read body failed: %w", err)
+	}
+
+	r, err := http.NewRequest(rf.method, rf.url, bytes.NewReader(body)) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+
+	for _, h := range rf.headers {
+		name, value, _ := strings.Cut(h, ":")
+		r.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	return r, nil
+}
+
+type headerFlags []string
+
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerFlags) Set(v string) error {
+	if !strings.Contains(v, ":") {
+		return fmt.Errorf(`header must be in "Name: value" form`) //nolint:err113
+	}
+
+	*h = append(*h, v)
+
+	return nil
+}
+
+func runSign(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var (
+		rf      requestFlags
+		keyFile string
+		version int
+		keyID   string
+		signed  string
+		at      int64
+	)
+
+	fs := newFlagSet("sign", stderr)
+	rf.register(fs)
+	fs.StringVar(&keyFile, "key", "", "PEM file containing private key")
+	fs.IntVar(&version, "version", int(sign.V4), "signature version")
+	fs.StringVar(&keyID, "key-id", "", "key ID to embed into signature, V5 and later")
+	fs.StringVar(&signed, "signed-headers", "", "comma-separated names of signed headers, V6 and later")
+	fs.Int64Var(&at, "time", 0, "signature timestamp as Unix time, current time by default")
+
+	if err := fs.Parse(args); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	signer, err := loadSigner(keyFile)
+	if err != nil {
+		return err
+	}
+
+	r, err := rf.request(stdin)
+	if err != nil {
+		return err
+	}
+
+	ts := time.Now()
+	if at != 0 {
+		ts = time.Unix(at, 0)
+	}
+
+	sig, err := signRequest(r, signer, sign.Version(version), ts, keyID, splitList(signed))
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(stdout, sig.HexString())
+
+	return nil
+}
+
+func signRequest(
+	r *http.Request, signer sign.Signer, v sign.Version, ts time.Time, keyID string, headers []string,
+) (sig sign.Signature, err error) {
+	// NewDigest panics on invalid parameters, which are user input here.
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%w: %v", errUsage, p)
+		}
+	}()
+
+	var opts []sign.Option
+
+	if keyID != "" {
+		opts = append(opts, sign.WithKeyID(keyID))
+	}
+
+	if len(headers) > 0 {
+		opts = append(opts, sign.WithSignedHeaders(headers...))
+	}
+
+	digest := sign.NewDigest(v, ts, opts...)
+
+	if err = digest.AddRequest(r); err != nil {
+		return nil, fmt.Errorf("read request failed: %w", err)
+	}
+
+	return digest.Sign(signer) //nolint:wrapcheck
+}
+
+func runVerify(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var (
+		rf        requestFlags
+		keyFile   string
+		signature string
+		leeway    time.Duration
+	)
+
+	fs := newFlagSet("verify", stderr)
+	rf.register(fs)
+	fs.StringVar(&keyFile, "key", "", "PEM file containing public key")
+	fs.StringVar(&signature, "signature", "", "hex signature to verify")
+	fs.DurationVar(&leeway, "leeway", sign.DefaultLeeway,
+		"maximum difference between signature timestamp and current time")
+
+	if err := fs.Parse(args); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if signature == "" {
+		return fmt.Errorf("%w: -signature is required", errUsage)
+	}
+
+	verifier, err := loadVerifier(keyFile)
+	if err != nil {
+		return err
+	}
+
+	r, err := rf.request(stdin)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set(sign.DefaultHeader, signature)
+
+	if err = sign.VerifyRequest(r, verifier, sign.WithLeeway(leeway)); err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(stdout, "OK")
+
+	return nil
+}
+
+func runInspect(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("inspect", stderr)
+
+	if err := fs.Parse(args); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: exactly one signature expected", errUsage)
+	}
+
+	sig, err := sign.ParseSignature(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("parse signature failed: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(stdout, "version:    %d\n", sig.Ver())
+	_, _ = fmt.Fprintf(stdout, "algorithm:  %s\n", sig.Alg())
+	_, _ = fmt.Fprintf(stdout, "time:       %s (%d)\n", sig.Time().UTC().Format(time.RFC3339), sig.Time().Unix())
+
+	if sig.Ver() >= sign.V5 {
+		_, _ = fmt.Fprintf(stdout, "key id:     %q\n", sig.KeyID())
+	}
+
+	if sig.Ver() >= sign.V6 {
+		_, _ = fmt.Fprintf(stdout, "headers:    %s\n", strings.Join(sig.SignedHeaders(), ","))
+	}
+
+	_, _ = fmt.Fprintf(stdout, "data:       %s\n", hex.EncodeToString(sig.Data()))
+
+	return nil
+}
+
+func newFlagSet(name string, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("httpsign "+name, flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	return fs
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	list := strings.Split(s, ",")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+
+	return list
+}
+
+func readPEM(file string) (*pem.Block, error) {
+	if file == "" {
+		return nil, fmt.Errorf("%w: -key is required", errUsage)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key failed: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errNoPEMBlock
+	}
+
+	return block, nil
+}
+
+func loadSigner(file string) (sign.Signer, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parse private key failed: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return (*sign.RSASigner)(key), nil
+	case ed25519.PrivateKey:
+		return sign.Ed25519Signer(key), nil
+	case *ecdsa.PrivateKey:
+		return (*sign.ECDSASigner)(key), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedKey, key)
+	}
+}
+
+func loadVerifier(file string) (sign.Verifier, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+
+	if block.Type == "RSA PUBLIC KEY" {
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parse public key failed: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return (*sign.RSAVerifier)(key), nil
+	case ed25519.PublicKey:
+		return sign.Ed25519Verifier(key), nil
+	case *ecdsa.PublicKey:
+		return (*sign.ECDSAVerifier)(key), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedKey, key)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, typ string, der []byte) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+
+	return file
+}
+
+type keyFiles struct {
+	name    string
+	private string
+	public  string
+}
+
+func generateKeys(t *testing.T) []keyFiles {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pkcs8 := func(key any) []byte {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		return der
+	}
+
+	pkix := func(key any) []byte {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+
+		return der
+	}
+
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+
+	return []keyFiles{
+		{
+			name:    "rsa pkcs1",
+			private: writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)),
+			public:  writePEM(t, "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)),
+		},
+		{
+			name:    "rsa pkcs8",
+			private: writePEM(t, "PRIVATE KEY", pkcs8(rsaKey)),
+			public:  writePEM(t, "PUBLIC KEY", pkix(&rsaKey.PublicKey)),
+		},
+		{
+			name:    "ed25519",
+			private: writePEM(t, "PRIVATE KEY", pkcs8(edPriv)),
+			public:  writePEM(t, "PUBLIC KEY", pkix(edPub)),
+		},
+		{
+			name:    "ecdsa",
+			private: writePEM(t, "EC PRIVATE KEY", ecDER),
+			public:  writePEM(t, "PUBLIC KEY", pkix(&ecKey.PublicKey)),
+		},
+	}
+}
+
+func runCmd(args []string, stdin string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	err := run(args, strings.NewReader(stdin), &stdout, &stderr)
+
+	return stdout.String(), err
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	bodyFile := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, os.WriteFile(bodyFile, []byte(`{"hello":"world"}`), 0o600))
+
+	request := []string{"-method", "POST", "-url", "https://example.com/path?q=1", "-body", bodyFile}
+
+	for _, keys := range generateKeys(t) {
+		t.Run(keys.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := runCmd(append([]string{"sign", "-key", keys.private}, request...), "")
+			require.NoError(t, err)
+
+			sig := strings.TrimSpace(out)
+
+			out, err = runCmd(append([]string{"verify", "-key", keys.public, "-signature", sig}, request...), "")
+			require.NoError(t, err)
+			assert.Equal(t, "OK\n", out)
+
+			tampered := []string{
+				"verify", "-key", keys.public, "-signature", sig,
+				"-method", "POST", "-url", "https://example.com/path?q=2", "-body", bodyFile,
+			}
+			_, err = runCmd(tampered, "")
+			require.Error(t, err)
+
+			out, err = runCmd([]string{"inspect", sig}, "")
+			require.NoError(t, err)
+			assert.Contains(t, out, "version:    4\n")
+			assert.Contains(t, out, "algorithm:  ")
+		})
+	}
+}
+
+func TestRun_V6(t *testing.T) {
+	t.Parallel()
+
+	keys := generateKeys(t)[2]
+	request := []string{
+		"-method", "PUT", "-url", "https://example.com/path", "-body", "-", "-H", "Content-Type: text/plain",
+	}
+
+	out, err := runCmd(append([]string{
+		"sign", "-key", keys.private, "-version", "6", "-key-id", "k1",
+		"-signed-headers", "Content-Type", "-time", "1000000",
+	}, request...), "payload")
+	require.NoError(t, err)
+
+	sig := strings.TrimSpace(out)
+
+	verify := []string{"verify", "-key", keys.public, "-signature", sig, "-leeway", "1000000h"}
+
+	out, err = runCmd(append(verify, request...), "payload")
+	require.NoError(t, err)
+	assert.Equal(t, "OK\n", out)
+
+	_, err = runCmd(append([]string{"verify", "-key", keys.public, "-signature", sig}, request...), "payload")
+	require.ErrorContains(t, err, "expired")
+
+	out, err = runCmd([]string{"inspect", sig}, "")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"version:    6",
+		"algorithm:  ed25519",
+		"time:       1970-01-12T13:46:40Z (1000000)",
+		`key id:     "k1"`,
+		"headers:    content-type",
+		"data:       ",
+	}, "\n"), out[:strings.Index(out, "data:")+12])
+	assert.Len(t, out[strings.Index(out, "data:")+12:], 2*ed25519.SignatureSize+1)
+}
+
+func TestRun_Errors(t *testing.T) {
+	t.Parallel()
+
+	keys := generateKeys(t)[2]
+
+	const target = "https://example.com"
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"unknown"}},
+		{name: "missing key", args: []string{"sign", "-url", target}},
+		{name: "missing url", args: []string{"sign", "-key", keys.private}},
+		{name: "invalid key file", args: []string{"sign", "-key", keys.private + ".missing", "-url", target}},
+		{name: "public key for signing", args: []string{"sign", "-key", keys.public, "-url", target}},
+		{name: "key id for old version", args: []string{"sign", "-key", keys.private, "-url", target, "-key-id", "k"}},
+		{name: "unsupported version", args: []string{"sign", "-key", keys.private, "-url", target, "-version", "9"}},
+		{name: "missing signature", args: []string{"verify", "-key", keys.public, "-url", target}},
+		{name: "invalid signature", args: []string{"inspect", "zz"}},
+		{name: "no signature to inspect", args: []string{"inspect"}},
+		{name: "invalid header", args: []string{"sign", "-H", "invalid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := runCmd(tt.args, "")
+			require.Error(t, err)
+		})
+	}
+}
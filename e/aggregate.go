@@ -0,0 +1,123 @@
+package e
+
+import (
+	"errors"
+	"strings"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+// Aggregate accumulates independent errors, such as validation failures, into
+// a single Err. The zero value is not usable, use [NewAggregate] instead.
+// Aggregate is not safe for concurrent use.
+//
+//	agg := e.NewAggregate("invalid config", fields.F("file", "app.yaml"))
+//	agg.Add(ErrMissingKey, fields.F("key", "db.host"))
+//	agg.Add(ErrInvalidValue, fields.F("key", "db.port"))
+//
+//	return agg.Err()
+//	// invalid config (file=app.yaml): [missing key (key=db.host); invalid value (key=db.port)]
+type Aggregate struct {
+	reason string
+	fields fields.List
+	errs   []error
+}
+
+// NewAggregate returns a new Aggregate, which produces Err with given reason and
+// fields.
+func NewAggregate(reason string, f ...fields.Field) *Aggregate {
+	return &Aggregate{
+		reason: reason,
+		fields: f,
+		errs:   nil,
+	}
+}
+
+// Add adds err to the aggregate, attaching fields to it. Identity of err is
+// preserved, so that [errors.Is] and [errors.As] match it within the aggregated
+// error. Nil errors are ignored.
+func (a *Aggregate) Add(err error, f ...fields.Field) {
+	if err == nil {
+		return
+	}
+
+	if len(f) > 0 {
+		err = &Err{
+			errs:   []error{err},
+			fields: f,
+			stack:  nil,
+			kind:   KindUnknown,
+		}
+	}
+
+	a.errs = append(a.errs, err)
+}
+
+// Len returns the number of errors added to the aggregate.
+func (a *Aggregate) Len() int {
+	return len(a.errs)
+}
+
+// Err returns an Err with the aggregate reason and fields, wrapping all added
+// errors, or nil in case none were added. Since the return value is an error
+// interface, it is safe to compare it with nil.
+//
+// An Err aggregating several errors is rendered with all of them listed:
+//
+//	<reason> (fields...): [<error 1>; <error 2>]
+//
+// while an Err aggregating a single error is the same as created with [NewFrom].
+func (a *Aggregate) Err() error {
+	if len(a.errs) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(a.errs)+1)
+	errs = append(errs, errors.New(a.reason)) //nolint:err113
+	errs = append(errs, a.errs...)
+
+	return newErr(errs, a.fields)
+}
+
+// Members returns the errors wrapped by the Err, which is either the single
+// wrapped error, all errors aggregated by [Aggregate], or nothing.
+func (e *Err) Members() []error {
+	if e == nil || len(e.errs) < 2 { //nolint:mnd
+		return nil
+	}
+
+	return e.errs[1:]
+}
+
+// isAggregate reports whether the Err wraps several errors.
+func (e *Err) isAggregate() bool {
+	return len(e.errs) > 2 //nolint:mnd
+}
+
+// members is the error passed to the logger as the wrapped error of an aggregate.
+type members []error
+
+func (m members) Error() string {
+	b := &strings.Builder{}
+	writeMembers(b, m, false)
+
+	return b.String()
+}
+
+func (m members) Unwrap() []error {
+	return m
+}
+
+func writeMembers(b *strings.Builder, errs []error, redacted bool) {
+	b.WriteRune('[')
+
+	for i, err := range errs {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+
+		writeTo(b, err, redacted)
+	}
+
+	b.WriteRune(']')
+}
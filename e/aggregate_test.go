@@ -0,0 +1,141 @@
+package e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/logger"
+	"dev.gaijin.team/go/golib/logger/bufferadapter"
+)
+
+var (
+	errMissingKey   = e.New("missing key")
+	errInvalidValue = e.New("invalid value")
+)
+
+func newConfigAggregate() *e.Aggregate {
+	agg := e.NewAggregate("invalid config", fields.F("file", "app.yaml"))
+	agg.Add(errMissingKey, fields.F("key", "db.host"))
+	agg.Add(nil)
+	agg.Add(errInvalidValue.Wrap(&fs.PathError{Op: "open", Path: "cert.pem", Err: os.ErrNotExist}))
+	agg.Add(errors.New("deprecated key")) //nolint:err113
+
+	return agg
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	agg := e.NewAggregate("empty")
+	assert.Equal(t, 0, agg.Len())
+	require.NoError(t, agg.Err())
+
+	agg = newConfigAggregate()
+	assert.Equal(t, 3, agg.Len())
+
+	err := agg.Err()
+	require.Error(t, err)
+	assert.Equal(t, "invalid config (file=app.yaml): "+
+		"[missing key (key=db.host); invalid value: open cert.pem: file does not exist; deprecated key]", err.Error())
+
+	require.ErrorIs(t, err, errMissingKey)
+	require.ErrorIs(t, err, errInvalidValue)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	var pathErr *fs.PathError
+	require.ErrorAs(t, err, &pathErr)
+	assert.Equal(t, "cert.pem", pathErr.Path)
+
+	var ee *e.Err
+	require.ErrorAs(t, err, &ee)
+	assert.Len(t, ee.Members(), 3)
+	assert.Equal(t, "invalid config", ee.Reason())
+	assert.Equal(t, fields.List{fields.F("file", "app.yaml"), fields.F("key", "db.host")},
+		e.ChainFields(err, e.CollisionKeepAll))
+
+	single := e.NewAggregate("invalid config")
+	single.Add(errMissingKey)
+	assert.Equal(t, "invalid config: missing key", single.Err().Error())
+	assert.Nil(t, e.New("err").Members())
+	assert.Equal(t, []error{errMissingKey}, e.NewFrom("err", errMissingKey).Members())
+}
+
+func TestAggregate_JSON(t *testing.T) {
+	t.Parallel()
+
+	err := newConfigAggregate().Err()
+
+	data, mErr := json.Marshal(err)
+	require.NoError(t, mErr)
+	assert.JSONEq(t, `{"reason":"invalid config","fields":{"file":"app.yaml"},"causes":[`+
+		`{"reason":"missing key","fields":{"key":"db.host"}},`+
+		`{"reason":"invalid value","cause":{"reason":"open cert.pem: file does not exist"}},`+
+		`{"reason":"deprecated key"}]}`, string(data))
+
+	var decoded e.Err
+
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, err.Error(), decoded.Error())
+	assert.Len(t, decoded.Members(), 3)
+
+	b := &bytes.Buffer{}
+	slog.New(slog.NewTextHandler(b, nil)).Info("msg", "err", err)
+	assert.Contains(t, b.String(), "err.causes.0.reason=\"missing key\" err.causes.0.fields.key=db.host "+
+		"err.causes.1.reason=\"invalid value\"")
+}
+
+func TestLog_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	adapter, buff := bufferadapter.New()
+	log := logger.New(adapter)
+
+	err := newConfigAggregate().Err()
+
+	e.Log(err, log.Error)
+	e.Log(err, log.Error, e.WithMembers(), e.WithChainFields(e.CollisionKeepAll))
+
+	assert.Equal(t, []bufferadapter.LogEntry{
+		{
+			Level: logger.LevelError,
+			Msg:   "invalid config",
+			Fields: fields.List{
+				fields.F("file", "app.yaml"),
+				fields.F("error", "[missing key (key=db.host); "+
+					"invalid value: open cert.pem: file does not exist; deprecated key]"),
+			},
+		},
+		{
+			Level: logger.LevelError,
+			Msg:   "invalid config",
+			Fields: fields.List{
+				fields.F("file", "app.yaml"),
+				fields.F("key", "db.host"),
+				fields.F("error", "missing key (key=db.host)"),
+			},
+		},
+		{
+			Level: logger.LevelError,
+			Msg:   "invalid config",
+			Fields: fields.List{
+				fields.F("file", "app.yaml"),
+				fields.F("error", "invalid value: open cert.pem: file does not exist"),
+			},
+		},
+		{
+			Level:  logger.LevelError,
+			Msg:    "invalid config",
+			Fields: fields.List{fields.F("file", "app.yaml"), fields.F("error", "deprecated key")},
+		},
+	}, buff.GetAll())
+}
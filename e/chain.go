@@ -0,0 +1,158 @@
+package e
+
+import (
+	"reflect"
+	"strconv"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+// Collision defines how [ChainFields] treats fields with the same key found on
+// different levels of the error chain.
+type Collision int
+
+const (
+	// CollisionKeepAll keeps all fields, even if their keys collide.
+	CollisionKeepAll Collision = iota
+	// CollisionKeepOuter keeps only the field closest to the chain top.
+	CollisionKeepOuter
+	// CollisionKeepInner keeps only the field closest to the error origin.
+	CollisionKeepInner
+	// CollisionPrefix keeps all fields, prefixing colliding keys found deeper in
+	// the chain with their level, e.g. "2.query".
+	CollisionPrefix
+)
+
+// ChainFields returns fields of all errors in err chain, walking it through
+// both Unwrap() error and Unwrap() []error methods. Fields are ordered from the
+// chain top to the error origin: fields of Err come before fields of errors it
+// wraps, and the context error passed to [Err.Wrap] comes before the wrapped
+// one.
+//
+// Fields with colliding keys are treated according to c. Fields of a single Err
+// are never considered colliding with each other.
+func ChainFields(err error, c Collision) fields.List {
+	var (
+		list fields.List
+		// owners maps keys to the Err which fields with that key are kept of.
+		owners = make(map[string]*Err)
+	)
+
+	walk(err, 0, func(ee *Err, level int) {
+		for _, f := range ee.fields {
+			owner, seen := owners[f.K]
+
+			if !seen || owner == ee || c == CollisionKeepAll {
+				owners[f.K] = ee
+				list = append(list, f)
+
+				continue
+			}
+
+			switch c { //nolint:exhaustive
+			case CollisionKeepInner:
+				owners[f.K] = ee
+				list = append(removeKey(list, f.K), f)
+			case CollisionPrefix:
+				f.K = strconv.Itoa(level) + "." + f.K
+				list = append(list, f)
+			}
+		}
+	})
+
+	return list
+}
+
+// FieldValue returns the value of the field with given key found in err chain,
+// along with whether it was found. The chain is walked the same way as
+// [ChainFields] does, so the value attached closest to the chain top wins.
+// Fields with values of types other than T are skipped.
+//
+// Values marked with [fields.Sensitive] are only returned for T being
+// [fields.SensitiveValue].
+//
+//	userID, ok := e.FieldValue[int](err, "user_id")
+func FieldValue[T any](err error, key string) (T, bool) {
+	var (
+		value T
+		found bool
+	)
+
+	walk(err, 0, func(ee *Err, _ int) {
+		if found {
+			return
+		}
+
+		for _, f := range ee.fields {
+			if f.K != key {
+				continue
+			}
+
+			val := f.Value()
+
+			// nil value is only assignable to interface types.
+			if val == nil && reflect.TypeFor[T]().Kind() == reflect.Interface {
+				found = true
+
+				return
+			}
+
+			if v, ok := val.(T); ok {
+				value, found = v, true
+
+				return
+			}
+		}
+	})
+
+	return value, found
+}
+
+// HasField reports whether a field with given key is present anywhere in err
+// chain, regardless of its value.
+func HasField(err error, key string) bool {
+	_, found := FieldValue[any](err, key)
+
+	return found
+}
+
+func removeKey(list fields.List, key string) fields.List {
+	n := 0
+
+	for _, f := range list {
+		if f.K != key {
+			list[n] = f
+			n++
+		}
+	}
+
+	return list[:n]
+}
+
+// walk calls fn for every Err in err chain in depth-first order, parents before
+// children, passing the level of the error in the chain.
+func walk(err error, level int, fn func(ee *Err, level int)) {
+	if err == nil {
+		return
+	}
+
+	ee, ok := err.(*Err) //nolint:errorlint
+	if ok && ee != nil {
+		fn(ee, level)
+
+		for _, wrapped := range ee.errs {
+			walk(wrapped, level+1, fn)
+		}
+
+		return
+	}
+
+	switch x := err.(type) { //nolint:errorlint
+	case interface{ Unwrap() error }:
+		walk(x.Unwrap(), level+1, fn)
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range x.Unwrap() {
+			walk(wrapped, level+1, fn)
+		}
+	}
+}
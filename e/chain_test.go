@@ -0,0 +1,172 @@
+package e_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/logger"
+	"dev.gaijin.team/go/golib/logger/bufferadapter"
+)
+
+func TestChainFields(t *testing.T) {
+	t.Parallel()
+
+	errDB := e.New("db error", fields.F("query", "SELECT 1"), fields.F("id", 2))
+	errRequest := e.New("request failed", fields.F("id", 1))
+	err := fmt.Errorf("handler: %w",
+		errRequest.Wrap(errDB, fields.F("attempt", 3)).WithField("id", 0),
+	)
+
+	tests := []struct {
+		name      string
+		collision e.Collision
+		expected  fields.List
+	}{
+		{
+			name:      "keep all",
+			collision: e.CollisionKeepAll,
+			expected: fields.List{
+				fields.F("id", 0), fields.F("attempt", 3), fields.F("id", 1), fields.F("query", "SELECT 1"), fields.F("id", 2),
+			},
+		},
+		{
+			name:      "keep outer",
+			collision: e.CollisionKeepOuter,
+			expected: fields.List{
+				fields.F("id", 0), fields.F("attempt", 3), fields.F("query", "SELECT 1"),
+			},
+		},
+		{
+			name:      "keep inner",
+			collision: e.CollisionKeepInner,
+			expected: fields.List{
+				fields.F("attempt", 3), fields.F("query", "SELECT 1"), fields.F("id", 2),
+			},
+		},
+		{
+			name:      "prefix",
+			collision: e.CollisionPrefix,
+			expected: fields.List{
+				fields.F("id", 0), fields.F("attempt", 3), fields.F("3.id", 1),
+				fields.F("query", "SELECT 1"), fields.F("3.id", 2),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, e.ChainFields(err, tt.collision))
+		})
+	}
+
+	t.Run("fields of single error never collide", func(t *testing.T) {
+		t.Parallel()
+
+		err := e.New("err", fields.F("id", 1), fields.F("id", 2)).Wrap(e.New("cause", fields.F("id", 3)))
+
+		assert.Equal(t, fields.List{fields.F("id", 1), fields.F("id", 2)}, e.ChainFields(err, e.CollisionKeepOuter))
+		assert.Equal(t, fields.List{fields.F("id", 3)}, e.ChainFields(err, e.CollisionKeepInner))
+	})
+
+	t.Run("no fields", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, e.ChainFields(nil, e.CollisionKeepAll))
+		assert.Empty(t, e.ChainFields(errors.New("err"), e.CollisionKeepAll)) //nolint:err113
+		assert.Empty(t, e.ChainFields(e.New("err"), e.CollisionKeepAll))
+	})
+}
+
+func TestLog_WithChainFields(t *testing.T) {
+	t.Parallel()
+
+	adapter, buff := bufferadapter.New()
+	log := logger.New(adapter)
+
+	errDB := e.New("db error", fields.F("query", "SELECT 1"))
+	err := e.NewFrom("request failed", errDB, fields.F("id", 1))
+
+	e.Log(err, log.Error, e.WithChainFields(e.CollisionKeepAll))
+	e.Log(fmt.Errorf("handler: %w", err), log.Error, e.WithChainFields(e.CollisionKeepAll))
+	e.Log(err, log.Error)
+
+	assert.Equal(t, []bufferadapter.LogEntry{
+		{
+			Level: logger.LevelError,
+			Msg:   "request failed",
+			Fields: fields.List{
+				fields.F("id", 1),
+				fields.F("query", "SELECT 1"),
+				fields.F("error", "db error (query=SELECT 1)"),
+			},
+		},
+		{
+			Level: logger.LevelError,
+			Msg:   "handler: request failed (id=1): db error (query=SELECT 1)",
+			Fields: fields.List{
+				fields.F("id", 1),
+				fields.F("query", "SELECT 1"),
+				fields.F("error", "request failed (id=1): db error (query=SELECT 1)"),
+			},
+		},
+		{
+			Level:  logger.LevelError,
+			Msg:    "request failed",
+			Fields: fields.List{fields.F("id", 1), fields.F("error", "db error (query=SELECT 1)")},
+		},
+	}, buff.GetAll())
+}
+
+func TestFieldValue(t *testing.T) {
+	t.Parallel()
+
+	errDB := e.New("db error", fields.F("user_id", 2), fields.F("query", "SELECT 1"), fields.F("nil", nil))
+	err := fmt.Errorf("handler: %w",
+		e.New("request failed", fields.F("user_id", "one")).Wrap(errDB, fields.Sensitive("token", "secret")),
+	)
+
+	userID, ok := e.FieldValue[int](err, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, 2, userID)
+
+	userName, ok := e.FieldValue[string](err, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, "one", userName)
+
+	value, ok := e.FieldValue[any](err, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, "one", value)
+
+	_, ok = e.FieldValue[float64](err, "user_id")
+	assert.False(t, ok)
+
+	_, ok = e.FieldValue[string](err, "token")
+	assert.False(t, ok)
+
+	token, ok := e.FieldValue[fields.SensitiveValue](err, "token")
+	assert.True(t, ok)
+	assert.Equal(t, "secret", token.Value())
+
+	value, ok = e.FieldValue[any](err, "nil")
+	assert.True(t, ok)
+	assert.Nil(t, value)
+
+	_, ok = e.FieldValue[string](err, "nil")
+	assert.False(t, ok)
+
+	_, ok = e.FieldValue[int](nil, "user_id")
+	assert.False(t, ok)
+
+	assert.True(t, e.HasField(err, "query"))
+	assert.True(t, e.HasField(err, "nil"))
+	assert.True(t, e.HasField(errors.Join(errors.New("other"), errDB), "query")) //nolint:err113
+	assert.False(t, e.HasField(err, "missing"))
+	assert.False(t, e.HasField(errors.New("err"), "query")) //nolint:err113
+}
@@ -35,12 +35,73 @@
 // Fields are always enclosed in parentheses, and wrapped errors are separated by
 // a colon and space.
 //
+// Other formats are provided by Formatter implementations: TextFormatter,
+// LogfmtFormatter and TreeFormatter, all of which can redact field values. Use
+// Format to render a single error, or SetFormatter to change the format of
+// Err.Error for all errors. Err also implements fmt.Formatter, where %+v verb
+// renders the error as a tree with stack traces.
+//
 // All methods that return errors create new instances; errors are immutable.
 //
 // Deprecated methods Unwrap, Is, and As are present for compatibility with the
 // errors package, but should not be used directly. Use the errors package
 // functions instead.
 //
+// Errors can carry the stack trace of the place they were created at. Capture
+// is disabled by default and is enabled either for all errors created by New,
+// NewFrom, From and Wrap with SetCaptureStack, or for a single error with
+// Err.WithStack:
+//
+//	e.SetCaptureStack(true)
+//	err := ErrJSONParseFailed.Wrap(err)
+//	e.StackOf(err) // stack trace captured by Wrap
+//
+// StackOf returns the stack trace captured closest to the error origin, loggers
+// pick it up automatically.
+//
+// Fields attached on every level of the error chain are collected with
+// ChainFields, which also resolves colliding keys according to the Collision
+// policy:
+//
+//	e.ChainFields(err, e.CollisionKeepInner) // (user_id=42 query=SELECT *)
+//
+// Single fields are looked up in the chain with FieldValue and HasField:
+//
+//	userID, ok := e.FieldValue[int](err, "user_id") // 42, true
+//
+// Independent errors are accumulated into a single Err with Aggregate, which
+// lists all of them and keeps them matchable with errors.Is and errors.As:
+//
+//	agg := e.NewAggregate("invalid config")
+//	agg.Add(ErrMissingKey, fields.F("key", "db.host"))
+//	agg.Add(ErrInvalidValue, fields.F("key", "db.port"))
+//	agg.Err() // "invalid config: [missing key (key=db.host); invalid value (key=db.port)]"
+//
+// Panics are converted into errors with Recover and Safe, which keep errors
+// passed to panic, such as ones produced by must package, matchable with
+// errors.Is:
+//
+//	func handle() (err error) {
+//		defer e.Recover(&err)
+//		must.NoErr(ErrJSONParseFailed) // err is "panic recovered: must.NoErr assertion failed: JSON parse failed"
+//	}
+//
+// Errors are classified with Kind for mapping to protocol status codes. The
+// kind is kept by derived errors, retrieved with KindOf and matched with
+// errors.Is:
+//
+//	var ErrUserNotFound = e.New("user not found").WithKind(e.KindNotFound)
+//
+//	err := ErrUserNotFound.WithField("user_id", 42)
+//	e.KindOf(err)                   // e.KindNotFound
+//	errors.Is(err, e.KindNotFound)  // true
+//	errors.Is(err, ErrUserNotFound) // true
+//
+// Err implements json.Marshaler and slog.LogValuer, representing the chain as
+// nested objects, and json.Unmarshaler to reconstruct it on the other side:
+//
+//	{"reason":"operation failed","fields":{"user_id":42},"cause":{"reason":"db error"}}
+//
 // The Log function logs errors using our logger abstraction - logger.Logger,
 // extracting reason, wrapped error, and fields, logging them appropriately.
 package e
@@ -6,31 +6,31 @@ import (
 	"strings"
 
 	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/stacktrace"
 )
 
 // Err represents a custom error type that supports error chaining and structured metadata fields.
 type Err struct {
 	errs   []error
 	fields fields.List
+	stack  *stacktrace.Stack
+	kind   Kind
 }
 
 // New returns a new Err with the given reason and optional fields.
 // The returned error can be further wrapped or annotated with additional fields.
 func New(reason string, f ...fields.Field) *Err {
-	return From(errors.New(reason), f...) //nolint:err113
+	return newErr([]error{errors.New(reason)}, f) //nolint:err113
 }
 
 // NewFrom returns a new Err with the given reason, wrapping the provided error, and optional fields.
 // If wrapped is nil, it behaves like New.
 func NewFrom(reason string, wrapped error, f ...fields.Field) *Err {
 	if wrapped == nil {
-		return New(reason, f...)
+		return newErr([]error{errors.New(reason)}, f) //nolint:err113
 	}
 
-	return &Err{
-		errs:   []error{errors.New(reason), wrapped}, //nolint:err113
-		fields: f,
-	}
+	return newErr([]error{errors.New(reason), wrapped}, f) //nolint:err113
 }
 
 // From converts any error to an Err, optionally adding fields. This is not true wrapping;
@@ -40,10 +40,7 @@ func From(origin error, f ...fields.Field) *Err {
 		origin = errors.New("error(nil)") //nolint:err113
 	}
 
-	return &Err{
-		errs:   []error{origin},
-		fields: f,
-	}
+	return newErr([]error{origin}, f)
 }
 
 // Wrap returns a new Err that wraps the provided error with the current Err as context.
@@ -53,25 +50,25 @@ func (e *Err) Wrap(err error, f ...fields.Field) *Err {
 		err = errors.New("error(nil)") //nolint:err113
 	}
 
-	return &Err{
-		errs:   []error{e, err},
-		fields: f,
-	}
+	return newErr([]error{e, err}, f)
 }
 
 // Error returns the string representation of the Err, including reason, fields, and wrapped errors.
+// The format can be changed with [SetFormatter].
 func (e *Err) Error() string {
+	if h := formatter.Load(); h != nil {
+		return Format(e, h.f)
+	}
+
 	b := &strings.Builder{}
-	writeTo(b, e)
+	writeTo(b, e, false)
 
 	return b.String()
 }
 
-func writeTo(b *strings.Builder, err error) {
-	if b.Len() > 0 {
-		b.WriteString(": ")
-	}
-
+// writeTo writes err in the default format, replacing field values with
+// [RedactedValue] in case redacted is set.
+func writeTo(b *strings.Builder, err error, redacted bool) {
 	ee, ok := err.(*Err) //nolint:errorlint
 	if !ok {
 		b.WriteString(err.Error())
@@ -79,7 +76,11 @@ func writeTo(b *strings.Builder, err error) {
 		return
 	}
 
-	b.WriteString(ee.Reason())
+	if inner, ok := ee.reasonErr().(*Err); ok && redacted { //nolint:errorlint
+		writeTo(b, inner, redacted)
+	} else {
+		b.WriteString(ee.Reason())
+	}
 
 	if ee == nil {
 		return
@@ -87,25 +88,43 @@ func writeTo(b *strings.Builder, err error) {
 
 	if len(ee.fields) > 0 {
 		b.WriteRune(' ')
-		ee.fields.WriteTo(b)
+		writeFields(b, ee.fields, redacted)
+	}
+
+	if ee.isAggregate() {
+		b.WriteString(": ")
+		writeMembers(b, ee.errs[1:], redacted)
+
+		return
 	}
 
 	if len(ee.errs) > 1 {
-		writeTo(b, ee.errs[1])
+		b.WriteString(": ")
+		writeTo(b, ee.errs[1], redacted)
 	}
 }
 
-// Clone returns a new Err with the same error, wrapped error, and a cloned fields container.
+// Clone returns a new Err with the same error, wrapped error, stack trace, kind
+// and a cloned fields container.
 func (e *Err) Clone() *Err {
 	return &Err{
 		errs:   slices.Clone(e.errs),
 		fields: slices.Clone(e.fields),
+		stack:  e.stack,
+		kind:   e.kind,
 	}
 }
 
 // WithFields returns a new Err with the same error and the provided fields.
+// The stack trace is never captured, since the wrapped Err already has one in
+// case capture is enabled.
 func (e *Err) WithFields(f ...fields.Field) *Err {
-	return From(e, f...)
+	return &Err{
+		errs:   []error{e},
+		fields: f,
+		stack:  nil,
+		kind:   KindUnknown,
+	}
 }
 
 // WithField returns a new Err with the same error and a single additional field.
@@ -118,6 +137,15 @@ func (e *Err) Fields() fields.List {
 	return e.fields
 }
 
+// reasonErr returns the error providing the reason of the Err, or nil.
+func (e *Err) reasonErr() error {
+	if e == nil || len(e.errs) == 0 {
+		return nil
+	}
+
+	return e.errs[0]
+}
+
 // Reason returns the reason string of the Err, without fields or wrapped errors.
 // If the Err is nil, returns "(*e.Err)(nil)". If empty, returns "(*e.Err)(empty)".
 func (e *Err) Reason() string {
@@ -0,0 +1,60 @@
+package e_test
+
+import (
+	"errors"
+	"testing"
+
+	"dev.gaijin.team/go/golib/e"
+)
+
+// goos: linux
+// goarch: amd64
+// pkg: dev.gaijin.team/go/golib/e
+// cpu: Intel(R) Xeon(R) Processor
+// Benchmark_Err/no_stack/New	200000	 138.6 ns/op	 96 B/op	3 allocs/op
+// Benchmark_Err/no_stack/Wrap	200000	 116.5 ns/op	 96 B/op	2 allocs/op
+// Benchmark_Err/stack/New		200000	  3874 ns/op	808 B/op	7 allocs/op
+// Benchmark_Err/stack/Wrap		200000	  3881 ns/op	808 B/op	6 allocs/op
+// Benchmark_Err/WithStack		200000	  3051 ns/op	712 B/op	4 allocs/op
+// PASS.
+//
+//nolint:paralleltest // modifies package-level capture setting
+func Benchmark_Err(b *testing.B) {
+	errCause := errors.New("cause") //nolint:err113
+	errBase := e.New("base")
+
+	for _, capture := range []bool{false, true} {
+		name := "no stack"
+		if capture {
+			name = "stack"
+		}
+
+		e.SetCaptureStack(capture)
+
+		b.Run(name+"/New", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = e.New("err")
+			}
+		})
+
+		b.Run(name+"/Wrap", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = errBase.Wrap(errCause)
+			}
+		})
+	}
+
+	e.SetCaptureStack(false)
+
+	b.Run("WithStack", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = errBase.WithStack()
+		}
+	})
+}
@@ -0,0 +1,301 @@
+package e
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/stacktrace"
+)
+
+// RedactedValue replaces field values rendered by formatters in redacted mode.
+const RedactedValue = fields.RedactedValue
+
+// Formatter renders errors as text.
+type Formatter interface {
+	// Format writes the text representation of err to b.
+	Format(b *strings.Builder, err error)
+}
+
+// formatterHolder allows storing formatters of different types in atomic.Pointer.
+type formatterHolder struct {
+	f Formatter
+}
+
+//nolint:gochecknoglobals
+var formatter atomic.Pointer[formatterHolder]
+
+// SetFormatter sets the formatter used by [Err.Error] for all errors. Passing
+// nil restores the default [TextFormatter].
+func SetFormatter(f Formatter) {
+	if f == nil {
+		formatter.Store(nil)
+
+		return
+	}
+
+	formatter.Store(&formatterHolder{f: f})
+}
+
+// Format returns err rendered by f. Returns an empty string if err is nil.
+func Format(err error, f Formatter) string {
+	if err == nil {
+		return ""
+	}
+
+	b := &strings.Builder{}
+	f.Format(b, err)
+
+	return b.String()
+}
+
+// Format implements [fmt.Formatter]. Verbs %s and %v render the error the same
+// as [Err.Error], %q renders it quoted, and %+v renders it with [TreeFormatter],
+// including stack traces.
+//
+//nolint:errcheck
+func (e *Err) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, Format(e, TreeFormatter{Indent: "", Redacted: false, Stack: true}))
+
+			return
+		}
+
+		io.WriteString(s, e.Error())
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		io.WriteString(s, strconv.Quote(e.Error()))
+	default:
+		fmt.Fprintf(s, "%%!%c(*e.Err=%s)", verb, e.Error())
+	}
+}
+
+// TextFormatter renders errors in the default single-line format:
+//
+//	<reason> (fields...): <wrapped error string>
+type TextFormatter struct {
+	// Redacted makes formatter replace field values with [RedactedValue].
+	Redacted bool
+}
+
+// Format implements [Formatter].
+func (f TextFormatter) Format(b *strings.Builder, err error) {
+	writeTo(b, err, f.Redacted)
+}
+
+// LogfmtFormatter renders errors as a single logfmt line, keyed by the same
+// structure [Err.MarshalJSON] produces:
+//
+//	reason="request failed" id=1 cause.reason="db error" cause.query="SELECT 1"
+//
+// Unlike [TextFormatter], fields of errors derived with [Err.WithFields] and
+// [Err.Wrap] are rendered together with the fields of the error they were
+// derived from. Values are quoted the same as [fields.List.EncodeLogfmt] does,
+// so the output can be parsed back with [fields.ParseLogfmt].
+type LogfmtFormatter struct {
+	// Redacted makes formatter replace field values with [RedactedValue].
+	Redacted bool
+}
+
+// Format implements [Formatter].
+func (f LogfmtFormatter) Format(b *strings.Builder, err error) {
+	var list fields.List
+
+	f.collect(&list, "", err)
+
+	_ = list.EncodeLogfmt(b)
+}
+
+// collect adds the reason and fields of every level of err chain to the list,
+// keyed by their position in the chain.
+func (f LogfmtFormatter) collect(list *fields.List, prefix string, err error) {
+	lv := levelOf(err)
+
+	list.Add(fields.String(prefix+jsonReason, lv.reasonText(f.Redacted)))
+
+	if lv.context != nil {
+		f.collect(list, prefix+jsonContext+".", lv.context)
+	}
+
+	for _, fl := range lv.fields {
+		if f.Redacted {
+			fl = fields.String(fl.K, RedactedValue)
+		}
+
+		fl.K = prefix + fl.K
+		list.Add(fl)
+	}
+
+	if len(lv.causes) == 1 {
+		f.collect(list, prefix+jsonCause+".", lv.causes[0])
+
+		return
+	}
+
+	for i, cause := range lv.causes {
+		f.collect(list, prefix+jsonCauses+"."+strconv.Itoa(i)+".", cause)
+	}
+}
+
+// TreeFormatter renders errors as a multi-line tree, where every wrapped error
+// is placed on its own line, indented deeper than the error wrapping it:
+//
+//	request failed (id=1)
+//	  db error (query=SELECT 1)
+//	    EOF
+//
+// Same as [LogfmtFormatter], fields of derived errors are rendered together
+// with the fields of the error they were derived from. The Err having causes of
+// its own, which is wrapped by [Err.Wrap], is rendered on a single line, same
+// as [TextFormatter] does, with the wrapped error placed below it.
+type TreeFormatter struct {
+	// Indent is the indentation of every tree level, two spaces by default.
+	Indent string
+	// Redacted makes formatter replace field values with [RedactedValue].
+	Redacted bool
+	// Stack makes formatter render stack traces captured for errors.
+	Stack bool
+}
+
+// Format implements [Formatter].
+func (f TreeFormatter) Format(b *strings.Builder, err error) {
+	if f.Indent == "" {
+		f.Indent = "  "
+	}
+
+	f.format(b, 0, err)
+}
+
+func (f TreeFormatter) format(b *strings.Builder, depth int, err error) {
+	lv := levelOf(err)
+
+	if b.Len() > 0 {
+		b.WriteByte('\n')
+	}
+
+	indent := strings.Repeat(f.Indent, depth)
+
+	b.WriteString(indent)
+	b.WriteString(lv.reasonText(f.Redacted))
+
+	if len(lv.fields) > 0 {
+		b.WriteByte(' ')
+		writeFields(b, lv.fields, f.Redacted)
+	}
+
+	if f.Stack && lv.stack != nil {
+		for line := range strings.SplitSeq(lv.stack.String(), "\n") {
+			b.WriteByte('\n')
+			b.WriteString(indent)
+			b.WriteString(f.Indent)
+			b.WriteString(line)
+		}
+	}
+
+	for _, cause := range lv.causes {
+		f.format(b, depth+1, cause)
+	}
+}
+
+// level is the normalized representation of a single level of error chain,
+// where errors derived with [Err.WithFields], [Err.WithStack], [Err.WithKind]
+// and [Err.Wrap] are merged with the Err they were derived from.
+//
+// The Err having causes of its own is not merged with the one wrapping other
+// errors, such as by [Err.Wrap], since its causes would become siblings of the
+// wrapped ones. Instead, it is kept as the context of the level, providing its
+// reason.
+type level struct {
+	reason  string
+	context *Err
+	kind    Kind
+	fields  fields.List
+	stack   *stacktrace.Stack
+	causes  []error
+}
+
+// reasonText returns the reason of the level, which is the context rendered
+// with [TextFormatter] in case there is one.
+func (lv level) reasonText(redacted bool) string {
+	if lv.context == nil {
+		return lv.reason
+	}
+
+	return Format(lv.context, TextFormatter{Redacted: redacted})
+}
+
+func levelOf(err error) level {
+	ee, ok := err.(*Err) //nolint:errorlint
+	if !ok {
+		return level{reason: err.Error(), context: nil, kind: KindUnknown, fields: nil, stack: nil, causes: nil}
+	}
+
+	if ee == nil || len(ee.errs) == 0 {
+		return level{reason: ee.Reason(), context: nil, kind: KindUnknown, fields: nil, stack: nil, causes: nil}
+	}
+
+	own := level{
+		reason:  ee.Reason(),
+		context: nil,
+		kind:    ee.kind,
+		fields:  ee.fields,
+		stack:   ee.stack,
+		causes:  ee.errs[1:],
+	}
+
+	inner, ok := ee.errs[0].(*Err) //nolint:errorlint
+	if !ok || inner == nil {
+		return own
+	}
+
+	lv := levelOf(inner)
+
+	if len(lv.causes) > 0 && len(own.causes) > 0 {
+		own.context = inner
+		// same as for merged levels, stack trace closer to the origin wins.
+		own.stack = cmp.Or(lv.stack, own.stack)
+
+		return own
+	}
+
+	lv.fields = append(slices.Clip(lv.fields), ee.fields...)
+	lv.causes = append(slices.Clip(lv.causes), ee.errs[1:]...)
+
+	// kind set closer to the chain top wins, same as for KindOf.
+	if ee.kind != KindUnknown {
+		lv.kind = ee.kind
+	}
+
+	// stack trace of the inner error is the one captured closer to the origin.
+	if lv.stack == nil {
+		lv.stack = ee.stack
+	}
+
+	return lv
+}
+
+// writeFields writes fields in the "(key1=val1, key2=val2)" format, replacing
+// their values with [RedactedValue] in case redacted is set.
+func writeFields(b *strings.Builder, list fields.List, redacted bool) {
+	if !redacted {
+		list.WriteTo(b)
+
+		return
+	}
+
+	fields.WriteTo(b, func(yield func(string, any) bool) {
+		for _, f := range list {
+			if !yield(f.K, RedactedValue) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package e_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func newFormatChain() error {
+	errDB := e.New("db error", fields.F("query", "SELECT 1")).Wrap(errors.New("EOF")) //nolint:err113
+	errRequest := e.New("request failed")
+
+	return errRequest.Wrap(errDB, fields.F("id", 1)).WithField("user", "bob")
+}
+
+func newFormatAggregate() error {
+	agg := e.NewAggregate("invalid config", fields.F("file", "app.yaml"))
+	agg.Add(e.New("missing key"), fields.F("key", "db.host"))
+	agg.Add(errors.New("deprecated key")) //nolint:err113
+
+	return agg.Err()
+}
+
+func TestFormatters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		err       error
+		formatter e.Formatter
+		expected  string
+	}{
+		{
+			name:      "text",
+			err:       newFormatChain(),
+			formatter: e.TextFormatter{Redacted: false},
+			expected:  "request failed (id=1): db error (query=SELECT 1): EOF (user=bob)",
+		},
+		{
+			name:      "text redacted",
+			err:       newFormatChain(),
+			formatter: e.TextFormatter{Redacted: true},
+			expected:  "request failed (id=[REDACTED]): db error (query=[REDACTED]): EOF (user=[REDACTED])",
+		},
+		{
+			name:      "text aggregate redacted",
+			err:       newFormatAggregate(),
+			formatter: e.TextFormatter{Redacted: true},
+			expected:  "invalid config (file=[REDACTED]): [missing key (key=[REDACTED]); deprecated key]",
+		},
+		{
+			name:      "text foreign",
+			err:       errors.New("foreign"), //nolint:err113
+			formatter: e.TextFormatter{Redacted: true},
+			expected:  "foreign",
+		},
+		{
+			name:      "logfmt",
+			err:       newFormatChain(),
+			formatter: e.LogfmtFormatter{Redacted: false},
+			expected: `reason="request failed" id=1 user=bob ` +
+				`cause.reason="db error" cause.query="SELECT 1" cause.cause.reason=EOF`,
+		},
+		{
+			name:      "logfmt redacted",
+			err:       newFormatChain(),
+			formatter: e.LogfmtFormatter{Redacted: true},
+			expected: `reason="request failed" id=[REDACTED] user=[REDACTED] ` +
+				`cause.reason="db error" cause.query=[REDACTED] cause.cause.reason=EOF`,
+		},
+		{
+			name:      "logfmt aggregate",
+			err:       newFormatAggregate(),
+			formatter: e.LogfmtFormatter{Redacted: false},
+			expected: `reason="invalid config" file=app.yaml causes.0.reason="missing key" causes.0.key=db.host ` +
+				`causes.1.reason="deprecated key"`,
+		},
+		{
+			name:      "logfmt quoting",
+			err:       e.New("a=b", fields.F("empty", ""), fields.F("quote", `"`), fields.F("line", "a\nb")),
+			formatter: e.LogfmtFormatter{Redacted: false},
+			expected:  `reason="a=b" empty="" quote="\"" line="a\nb"`,
+		},
+		{
+			name:      "logfmt null and backslash",
+			err:       e.New("err", fields.F("null", "null"), fields.F("path", `C:\dir`), fields.F("nil", nil)),
+			formatter: e.LogfmtFormatter{Redacted: false},
+			expected:  `reason=err null="null" path="C:\\dir" nil=null`,
+		},
+		{
+			name:      "tree",
+			err:       newFormatChain(),
+			formatter: e.TreeFormatter{Indent: "", Redacted: false, Stack: false},
+			expected: "request failed (id=1, user=bob)\n" +
+				"  db error (query=SELECT 1)\n" +
+				"    EOF",
+		},
+		{
+			name:      "tree context with cause",
+			err:       e.NewFrom("a", e.New("b", fields.F("id", 1))).Wrap(e.New("c")),
+			formatter: e.TreeFormatter{Indent: "", Redacted: true, Stack: false},
+			expected: "a: b (id=[REDACTED])\n" +
+				"  c",
+		},
+		{
+			name:      "logfmt context with cause",
+			err:       e.NewFrom("a", e.New("b", fields.F("id", 1))).Wrap(e.New("c")),
+			formatter: e.LogfmtFormatter{Redacted: false},
+			expected:  `reason="a: b (id=1)" context.reason=a context.cause.reason=b context.cause.id=1 cause.reason=c`,
+		},
+		{
+			name:      "tree aggregate redacted",
+			err:       newFormatAggregate(),
+			formatter: e.TreeFormatter{Indent: "\t", Redacted: true, Stack: false},
+			expected: "invalid config (file=[REDACTED])\n" +
+				"\tmissing key (key=[REDACTED])\n" +
+				"\tdeprecated key",
+		},
+		{
+			name:      "nil",
+			err:       nil,
+			formatter: e.TreeFormatter{}, //nolint:exhaustruct
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, e.Format(tt.err, tt.formatter))
+		})
+	}
+}
+
+func TestTreeFormatter_Stack(t *testing.T) {
+	t.Parallel()
+
+	inner := newInner().WithStack()
+	err := e.New("outer").Wrap(inner)
+
+	out := e.Format(err, e.TreeFormatter{Indent: "", Redacted: false, Stack: true})
+	lines := strings.Split(out, "\n")
+
+	assert.Equal(t, "outer", lines[0])
+	assert.Equal(t, "  inner", lines[1])
+	assert.Equal(t, "    dev.gaijin.team/go/golib/e_test.TestTreeFormatter_Stack", lines[2])
+	assert.True(t, strings.HasPrefix(lines[3], "    \t"), lines[3])
+}
+
+func TestErr_Format(t *testing.T) {
+	t.Parallel()
+
+	err := e.New("outer", fields.F("id", 1)).Wrap(newInner().WithStack())
+
+	assert.Equal(t, "outer (id=1): inner", fmt.Sprintf("%s", err)) //nolint:gosimple
+	assert.Equal(t, "outer (id=1): inner", fmt.Sprintf("%v", err))
+	assert.Equal(t, `"outer (id=1): inner"`, fmt.Sprintf("%q", err))
+	assert.Equal(t, "%!d(*e.Err=outer (id=1): inner)", fmt.Sprintf("%d", err))
+	assert.Equal(t, "wrapped: outer (id=1): inner", fmt.Errorf("wrapped: %w", err).Error())
+	assert.Equal(t, e.Format(err, e.TreeFormatter{Indent: "", Redacted: false, Stack: true}), fmt.Sprintf("%+v", err))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "\n  inner\n    dev.gaijin.team/go/golib/e_test.TestErr_Format\n")
+}
+
+//nolint:paralleltest // modifies package-level formatter
+func TestSetFormatter(t *testing.T) {
+	err := newFormatChain()
+
+	e.SetFormatter(e.LogfmtFormatter{Redacted: true})
+	t.Cleanup(func() { e.SetFormatter(nil) })
+
+	assert.Equal(t, e.Format(err, e.LogfmtFormatter{Redacted: true}), err.Error())
+
+	e.SetFormatter(nil)
+
+	assert.Equal(t, "request failed (id=1): db error (query=SELECT 1): EOF (user=bob)", err.Error())
+}
+
+func TestLogfmtFormatter_ParseLogfmt(t *testing.T) {
+	t.Parallel()
+
+	err := e.New("request failed", fields.F("null", "null"), fields.F("path", `C:\dir`)).
+		Wrap(e.New("db error", fields.F("query", "SELECT 1")))
+
+	list, pErr := fields.ParseLogfmt(e.Format(err, e.LogfmtFormatter{Redacted: false}))
+	require.NoError(t, pErr)
+
+	assert.Equal(t, fields.List{
+		fields.F("reason", "request failed"),
+		fields.F("null", "null"),
+		fields.F("path", `C:\dir`),
+		fields.F("cause.reason", "db error"),
+		fields.F("cause.query", "SELECT 1"),
+	}, list)
+}
+
+func TestFormatters_Sensitive(t *testing.T) {
+	t.Parallel()
+
+	err := e.New("auth failed", fields.Sensitive("token", "secret"), fields.F("user", "bob"))
+
+	assert.Equal(t, "auth failed (token=[REDACTED], user=bob)", err.Error())
+	assert.Equal(t, `reason="auth failed" token=[REDACTED] user=bob`, e.Format(err, e.LogfmtFormatter{Redacted: false}))
+	assert.NotContains(t, fmt.Sprintf("%+v", err), "secret")
+
+	data, mErr := err.MarshalJSON()
+	assert.NoError(t, mErr)
+	assert.JSONEq(t, `{"reason":"auth failed","fields":{"token":"[REDACTED]","user":"bob"}}`, string(data))
+
+	assert.Equal(t, "secret", fields.Raw(err.Fields()[0].V))
+}
@@ -0,0 +1,296 @@
+package e
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+// JSON object keys of marshalled Err.
+const (
+	jsonReason  = "reason"
+	jsonContext = "context"
+	jsonKind    = "kind"
+	jsonFields  = "fields"
+	jsonCause   = "cause"
+	jsonCauses  = "causes"
+)
+
+var errInvalidJSON = errors.New("invalid error JSON")
+
+// MarshalJSON implements [json.Marshaler]. The Err is represented as a nested
+// object of the whole chain:
+//
+//	{"reason":"request failed","kind":"not-found","fields":{"id":1},"cause":{"reason":"db error"}}
+//
+// Fields keep their order and are omitted if there are none, same as the kind
+// and the cause. Errors derived with [Err.WithFields] and [Err.Wrap] are
+// represented together with the Err they were derived from, same as
+// [LogfmtFormatter] does, unless the Err wrapped by [Err.Wrap] has causes of its
+// own. Such Err is represented as "context" object, while the reason holds its
+// text. Errors aggregated by [Aggregate] are represented as "causes" array
+// instead of a single cause.
+// Errors of other types in the chain are represented by their text only. Field
+// values that cannot be marshalled are represented by their string form.
+func (e *Err) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+
+	b := &bytes.Buffer{}
+	writeJSON(b, e)
+
+	return b.Bytes(), nil
+}
+
+func writeJSON(b *bytes.Buffer, err error) {
+	lv := levelOf(err)
+
+	b.WriteString(`{"` + jsonReason + `":`)
+	writeJSONString(b, lv.reasonText(false))
+
+	if lv.context != nil {
+		b.WriteString(`,"` + jsonContext + `":`)
+		writeJSON(b, lv.context)
+	}
+
+	if lv.kind != KindUnknown {
+		b.WriteString(`,"` + jsonKind + `":`)
+		writeJSONString(b, string(lv.kind))
+	}
+
+	if len(lv.fields) > 0 {
+		b.WriteString(`,"` + jsonFields + `":`)
+		_ = lv.fields.EncodeJSON(b)
+	}
+
+	switch len(lv.causes) {
+	case 0:
+	case 1:
+		b.WriteString(`,"` + jsonCause + `":`)
+		writeJSON(b, lv.causes[0])
+	default:
+		b.WriteString(`,"` + jsonCauses + `":[`)
+
+		for i, cause := range lv.causes {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			writeJSON(b, cause)
+		}
+
+		b.WriteByte(']')
+	}
+
+	b.WriteByte('}')
+}
+
+// writeJSONString writes s as JSON string.
+func writeJSONString(b *bytes.Buffer, s string) {
+	data, _ := json.Marshal(s)
+	b.Write(data)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler], reconstructing the Err chain
+// marshalled by [Err.MarshalJSON]. Every level of the chain becomes an Err with
+// the original reason, kind and fields, which keep their order. Errors derived
+// from each other are restored as a single Err, while the context is restored as
+// the Err wrapping the cause. Fields are decoded the same as [fields.ParseJSON]
+// does.
+//
+// Since reasons are restored as plain text, reconstructed errors do not match
+// the original ones with [errors.Is].
+func (e *Err) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	decoded, err := readJSON(dec)
+	if err != nil {
+		return err
+	}
+
+	*e = *decoded
+
+	return nil
+}
+
+func readJSON(dec *json.Decoder) (*Err, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	lv := &jsonLevel{reason: "", context: nil, kind: KindUnknown, fields: nil, causes: nil}
+
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = lv.readMember(dec, key); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidJSON, err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	var reason error = errors.New(lv.reason) //nolint:err113
+	if lv.context != nil {
+		reason = lv.context
+	}
+
+	errs := append([]error{reason}, lv.causes...)
+
+	return &Err{
+		errs:   errs,
+		fields: lv.fields,
+		stack:  nil,
+		kind:   lv.kind,
+	}, nil
+}
+
+// jsonLevel holds members of a single level of marshalled Err being read.
+type jsonLevel struct {
+	reason  string
+	context *Err
+	kind    Kind
+	fields  fields.List
+	causes  []error
+}
+
+// readMember reads the value of the member with given key, skipping unknown
+// ones.
+func (lv *jsonLevel) readMember(dec *json.Decoder, key string) error {
+	var err error
+
+	switch key {
+	case jsonReason:
+		err = dec.Decode(&lv.reason)
+	case jsonContext:
+		lv.context, err = readJSON(dec)
+	case jsonKind:
+		err = dec.Decode(&lv.kind)
+	case jsonFields:
+		lv.fields, err = readFields(dec)
+	case jsonCause:
+		var cause *Err
+		if cause, err = readJSON(dec); err == nil {
+			lv.causes = []error{cause}
+		}
+	case jsonCauses:
+		lv.causes, err = readCauses(dec)
+	default:
+		var skip json.RawMessage
+		err = dec.Decode(&skip)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+func readCauses(dec *json.Decoder) ([]error, error) {
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+
+	var causes []error
+
+	for dec.More() {
+		cause, err := readJSON(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		causes = append(causes, cause)
+	}
+
+	return causes, expectDelim(dec, ']')
+}
+
+func readFields(dec *json.Decoder) (fields.List, error) {
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return fields.ParseJSON(raw) //nolint:wrapcheck
+}
+
+func readKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errInvalidJSON, err)
+	}
+
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: unexpected %v", errInvalidJSON, tok)
+	}
+
+	return key, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidJSON, err)
+	}
+
+	if tok != delim {
+		return fmt.Errorf("%w: expected %v, got %v", errInvalidJSON, delim, tok)
+	}
+
+	return nil
+}
+
+// LogValue implements [slog.LogValuer], representing the Err chain as nested
+// groups with the same structure as [Err.MarshalJSON] produces. Aggregated
+// errors are represented as a group keyed by their indexes.
+func (e *Err) LogValue() slog.Value {
+	if e == nil {
+		return slog.StringValue(e.Reason())
+	}
+
+	return logValue(e)
+}
+
+func logValue(err error) slog.Value {
+	lv := levelOf(err)
+
+	attrs := make([]slog.Attr, 0, 5) //nolint:mnd
+	attrs = append(attrs, slog.String(jsonReason, lv.reasonText(false)))
+
+	if lv.context != nil {
+		attrs = append(attrs, slog.Attr{Key: jsonContext, Value: logValue(lv.context)})
+	}
+
+	if lv.kind != KindUnknown {
+		attrs = append(attrs, slog.String(jsonKind, string(lv.kind)))
+	}
+
+	if len(lv.fields) > 0 {
+		attrs = append(attrs, slog.Attr{Key: jsonFields, Value: slog.GroupValue(lv.fields.Attrs()...)})
+	}
+
+	switch len(lv.causes) {
+	case 0:
+	case 1:
+		attrs = append(attrs, slog.Attr{Key: jsonCause, Value: logValue(lv.causes[0])})
+	default:
+		causes := make([]slog.Attr, 0, len(lv.causes))
+		for i, cause := range lv.causes {
+			causes = append(causes, slog.Attr{Key: strconv.Itoa(i), Value: logValue(cause)})
+		}
+
+		attrs = append(attrs, slog.Attr{Key: jsonCauses, Value: slog.GroupValue(causes...)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
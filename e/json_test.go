@@ -0,0 +1,261 @@
+package e_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func TestErr_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	errDB := e.New("db error", fields.F("query", "SELECT 1"))
+	errRequest := e.New("request failed")
+
+	tests := []struct {
+		name     string
+		in       *e.Err
+		expected string
+	}{
+		{
+			name:     "nil",
+			in:       nil,
+			expected: `null`,
+		},
+		{
+			name:     "reason only",
+			in:       e.New("err"),
+			expected: `{"reason":"err"}`,
+		},
+		{
+			name:     "fields keep order",
+			in:       e.New("err", fields.F("b", 1), fields.F("a", "x"), fields.F("c", []int{1, 2})),
+			expected: `{"reason":"err","fields":{"b":1,"a":"x","c":[1,2]}}`,
+		},
+		{
+			name: "chain",
+			in:   errRequest.Wrap(errDB, fields.F("id", 1)),
+			expected: `{"reason":"request failed","fields":{"id":1},` +
+				`"cause":{"reason":"db error","fields":{"query":"SELECT 1"}}}`,
+		},
+		{
+			name:     "foreign cause",
+			in:       e.NewFrom("err", fmt.Errorf("wrapped: %w", errDB)),
+			expected: `{"reason":"err","cause":{"reason":"wrapped: db error (query=SELECT 1)"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(tt.in)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+
+	t.Run("values that cannot be marshalled", func(t *testing.T) {
+		t.Parallel()
+
+		ch := make(chan int)
+		err := e.New("err", fields.F("err", errors.New("cause")), fields.F("ch", ch)) //nolint:err113
+
+		data, mErr := json.Marshal(err)
+		require.NoError(t, mErr)
+		assert.Equal(t, `{"reason":"err","fields":{"err":"cause","ch":"`+fmt.Sprint(ch)+`"}}`, string(data))
+	})
+}
+
+func TestErr_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		orig := e.New("request failed", fields.F("b", 1)).Wrap(
+			e.New("db error", fields.F("query", "SELECT 1"), fields.F("args", []any{"a", 2.5})),
+			fields.F("id", "x"),
+		)
+
+		data, err := json.Marshal(orig)
+		require.NoError(t, err)
+
+		var decoded *e.Err
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		// fields of the context error are merged with the ones passed to Wrap.
+		assert.Equal(t, "request failed (b=1, id=x): db error (query=SELECT 1, args=[a 2.5])", decoded.Error())
+		assert.Equal(t, fields.List{fields.F("b", json.Number("1")), fields.F("id", "x")}, decoded.Fields())
+
+		assert.Equal(t, fields.List{
+			fields.F("b", json.Number("1")),
+			fields.F("id", "x"),
+			fields.F("query", "SELECT 1"),
+			fields.F("args", []any{"a", json.Number("2.5")}),
+		}, e.ChainFields(decoded, e.CollisionKeepAll))
+
+		again, err := json.Marshal(decoded)
+		require.NoError(t, err)
+		assert.Equal(t, string(data), string(again))
+	})
+
+	t.Run("context error with fields", func(t *testing.T) {
+		t.Parallel()
+
+		orig := e.New("operation failed").WithField("user_id", 42).Wrap(e.New("db error"))
+
+		data, err := json.Marshal(orig)
+		require.NoError(t, err)
+		assert.Equal(t, `{"reason":"operation failed","fields":{"user_id":42},"cause":{"reason":"db error"}}`, string(data))
+
+		var decoded *e.Err
+		require.NoError(t, json.Unmarshal(data, &decoded))
+
+		userID, ok := e.FieldValue[json.Number](decoded, "user_id")
+		assert.True(t, ok)
+		assert.Equal(t, json.Number("42"), userID)
+		assert.Equal(t, "operation failed", decoded.Reason())
+	})
+
+	t.Run("nested chains", func(t *testing.T) {
+		t.Parallel()
+
+		agg := e.NewAggregate("agg")
+		agg.Add(e.New("m1"))
+		agg.Add(e.New("m2"))
+
+		var aggErr *e.Err
+		require.ErrorAs(t, agg.Err(), &aggErr)
+
+		tests := []struct {
+			name     string
+			in       *e.Err
+			expected string
+		}{
+			{
+				name: "context with cause",
+				in:   e.NewFrom("a", e.New("b")).Wrap(e.New("c"), fields.F("id", 1)),
+				expected: `{"reason":"a: b","context":{"reason":"a","cause":{"reason":"b"}},` +
+					`"fields":{"id":1},"cause":{"reason":"c"}}`,
+			},
+			{
+				name: "wrapped aggregate",
+				in:   aggErr.Wrap(e.New("x")),
+				expected: `{"reason":"agg: [m1; m2]","context":{"reason":"agg","causes":[{"reason":"m1"},{"reason":"m2"}]},` +
+					`"cause":{"reason":"x"}}`,
+			},
+		}
+
+		for _, tt := range tests {
+			data, err := json.Marshal(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data), tt.name)
+
+			var decoded *e.Err
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, tt.in.Error(), decoded.Error(), tt.name)
+
+			again, err := json.Marshal(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, string(data), string(again), tt.name)
+		}
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		t.Parallel()
+
+		var body struct {
+			Error *e.Err `json:"error"`
+		}
+
+		data := `{"error":{"extra":true,"reason":"err","fields":{"n":12345678901234567890}}}`
+
+		require.NoError(t, json.Unmarshal([]byte(data), &body))
+		assert.Equal(t, "err (n=12345678901234567890)", body.Error.Error())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, data := range []string{
+			`[]`,
+			`{"reason":1}`,
+			`{"reason":"err","fields":[]}`,
+			`{"reason":"err","cause":"cause"}`,
+		} {
+			var err e.Err
+			assert.Error(t, json.Unmarshal([]byte(data), &err), data)
+		}
+	})
+}
+
+func TestErr_LogValue(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	log := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	err := e.New("request failed", fields.F("op", "get")).Wrap(
+		fmt.Errorf("context: %w", e.New("db error")),
+		fields.F("id", 1),
+	)
+
+	log.Info("msg", slog.Any("error", err), slog.Any("nil", (*e.Err)(nil)))
+
+	assert.JSONEq(t, `{
+		"msg": "msg",
+		"error": {
+			"reason": "request failed",
+			"fields": {"op": "get", "id": 1},
+			"cause": {"reason": "context: db error"}
+		},
+		"nil": "(*e.Err)(nil)"
+	}`, buf.String())
+}
+
+func TestErr_MarshalJSON_Groups(t *testing.T) {
+	t.Parallel()
+
+	err := e.New("err",
+		fields.F("id", 1),
+		fields.Group("request", fields.F("method", "GET")),
+		fields.Namespace("ctx"),
+		fields.F("trace", "t1"),
+	)
+
+	data, mErr := json.Marshal(err)
+	require.NoError(t, mErr)
+	assert.Equal(t, `{"reason":"err","fields":{"id":1,"request":{"method":"GET"},"ctx":{"trace":"t1"}}}`, string(data))
+
+	var decoded *e.Err
+
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, fields.List{
+		fields.F("id", json.Number("1")),
+		fields.Group("request", fields.F("method", "GET")),
+		fields.Group("ctx", fields.F("trace", "t1")),
+	}, decoded.Fields())
+
+	b := &bytes.Buffer{}
+	slog.New(slog.NewTextHandler(b, nil)).Info("msg", "err", err)
+	assert.Contains(t, b.String(), "err.fields.id=1 err.fields.request.method=GET err.fields.ctx.trace=t1")
+}
@@ -0,0 +1,83 @@
+package e
+
+// Kind is a machine-readable category of an error, used to map errors to
+// protocol status codes, such as HTTP or gRPC ones. Kind implements error,
+// therefore it can be matched with [errors.Is]:
+//
+//	var ErrUserNotFound = e.New("user not found").WithKind(e.KindNotFound)
+//
+//	errors.Is(ErrUserNotFound.WithField("id", 42), e.KindNotFound) // true
+type Kind string
+
+// Predefined error kinds. Custom kinds can be declared the same way, it is
+// advised to keep them in kebab-case.
+const (
+	KindUnknown          Kind = ""
+	KindInvalidArgument  Kind = "invalid-argument"
+	KindNotFound         Kind = "not-found"
+	KindConflict         Kind = "conflict"
+	KindUnauthenticated  Kind = "unauthenticated"
+	KindPermissionDenied Kind = "permission-denied"
+	KindUnavailable      Kind = "unavailable"
+	KindDeadlineExceeded Kind = "deadline-exceeded"
+	KindInternal         Kind = "internal"
+)
+
+// Error implements error interface, returning the kind name.
+func (k Kind) Error() string {
+	return string(k)
+}
+
+// WithKind returns a new Err with the same error, classified with the given
+// kind. The kind is kept by errors derived from the returned one with
+// [Err.WithFields] or [Err.Wrap], and can be retrieved with [KindOf].
+func (e *Err) WithKind(k Kind) *Err {
+	return &Err{
+		errs:   []error{e},
+		fields: nil,
+		stack:  nil,
+		kind:   k,
+	}
+}
+
+// Kind returns the kind the Err was classified with by [Err.WithKind], or
+// [KindUnknown]. Use [KindOf] to get the kind of the whole chain.
+func (e *Err) Kind() Kind {
+	if e == nil {
+		return KindUnknown
+	}
+
+	return e.kind
+}
+
+// KindOf returns the kind of err, which is the one closest to the chain top,
+// since outer errors may reclassify the inner ones. Returns [KindUnknown] if
+// none of errors in chain has kind.
+func KindOf(err error) Kind {
+	kind := KindUnknown
+
+	walk(err, 0, func(ee *Err, _ int) {
+		if kind == KindUnknown {
+			kind = ee.kind
+		}
+	})
+
+	return kind
+}
+
+// Is reports whether target is the kind of the Err, which makes [errors.Is]
+// report whether the kind is present anywhere in the chain. Identity of errors
+// derived with [Err.WithFields], [Err.WithKind] or [Err.Wrap] is preserved, so
+// they still match the sentinel they were derived from:
+//
+//	errors.Is(ErrUserNotFound.WithField("id", 42), ErrUserNotFound) // true
+//
+// Deprecated: This method is for internal use only. Prefer using the errors package directly.
+func (e *Err) Is(target error) bool {
+	k, ok := target.(Kind) //nolint:errorlint
+	if !ok || e == nil {
+		return false
+	}
+
+	return e.kind != KindUnknown && e.kind == k
+}
@@ -0,0 +1,115 @@
+package e_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/e"
+)
+
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	errNotFound := e.New("not found").WithKind(e.KindNotFound)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected e.Kind
+	}{
+		{name: "nil", err: nil, expected: e.KindUnknown},
+		{name: "foreign error", err: errors.New("err"), expected: e.KindUnknown}, //nolint:err113
+		{name: "no kind", err: e.New("err"), expected: e.KindUnknown},
+		{name: "kind", err: errNotFound, expected: e.KindNotFound},
+		{name: "with fields", err: errNotFound.WithField("id", 1), expected: e.KindNotFound},
+		{name: "wrapped", err: e.New("outer").Wrap(errNotFound), expected: e.KindNotFound},
+		{name: "foreign wrapper", err: fmt.Errorf("ctx: %w", errNotFound), expected: e.KindNotFound},
+		{
+			name:     "reclassified",
+			err:      e.New("outer").Wrap(errNotFound).WithKind(e.KindInternal),
+			expected: e.KindInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, e.KindOf(tt.err))
+		})
+	}
+}
+
+func TestErr_Kind(t *testing.T) {
+	t.Parallel()
+
+	err := e.New("err").WithKind(e.KindConflict)
+
+	assert.Equal(t, e.KindConflict, err.Kind())
+	assert.Equal(t, e.KindConflict, err.Clone().Kind())
+	assert.Equal(t, e.KindUnknown, err.WithField("foo", "bar").Kind())
+	assert.Equal(t, e.KindUnknown, (*e.Err)(nil).Kind())
+	assert.Equal(t, "err", err.Error())
+	assert.Equal(t, "conflict", e.KindConflict.Error())
+}
+
+func TestErr_Is(t *testing.T) {
+	t.Parallel()
+
+	errNotFound := e.New("not found").WithKind(e.KindNotFound)
+	errBase := e.New("base")
+
+	err := e.New("outer").Wrap(errNotFound.WithField("id", 1)).WithKind(e.KindInternal)
+
+	require.ErrorIs(t, err, e.KindNotFound)
+	require.ErrorIs(t, err, e.KindInternal)
+	require.ErrorIs(t, err, errNotFound)
+	require.NotErrorIs(t, err, e.KindConflict)
+	require.NotErrorIs(t, errBase, e.KindUnknown)
+
+	require.ErrorIs(t, errBase.WithField("foo", "bar"), errBase)
+	require.ErrorIs(t, errBase.WithKind(e.KindConflict).WithField("foo", "bar"), errBase)
+	require.NotErrorIs(t, e.New("base"), errBase)
+}
+
+func TestErr_JSON_Kind(t *testing.T) {
+	t.Parallel()
+
+	err := e.New("outer").Wrap(e.New("not found").WithKind(e.KindNotFound))
+
+	data, mErr := json.Marshal(err)
+	require.NoError(t, mErr)
+	assert.JSONEq(t, `{"reason":"outer","cause":{"reason":"not found","kind":"not-found"}}`, string(data))
+
+	var decoded e.Err
+
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, e.KindNotFound, e.KindOf(&decoded))
+	assert.Equal(t, err.Error(), decoded.Error())
+}
+
+func TestErr_JSON_DerivedKind(t *testing.T) {
+	t.Parallel()
+
+	err := e.New("user not found").WithKind(e.KindNotFound).WithField("user_id", 42)
+
+	data, mErr := json.Marshal(err)
+	require.NoError(t, mErr)
+	assert.Equal(t, `{"reason":"user not found","kind":"not-found","fields":{"user_id":42}}`, string(data))
+
+	var decoded *e.Err
+
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, e.KindNotFound, e.KindOf(decoded))
+	require.ErrorIs(t, decoded, e.KindNotFound)
+
+	// kind set on the derived error wins over the one it was derived from.
+	data, mErr = json.Marshal(err.WithKind(e.KindConflict))
+	require.NoError(t, mErr)
+	assert.Contains(t, string(data), `"kind":"conflict"`)
+}
@@ -1,33 +1,153 @@
 package e
 
 import (
+	"errors"
+
 	"dev.gaijin.team/go/golib/fields"
 )
 
 // ErrorLogger defines a function that logs an error message, an error, and optional fields.
 type ErrorLogger func(msg string, err error, fs ...fields.Field)
 
+// LogOption is a functional option for configuring [Log] behavior.
+type LogOption func(*logConfig)
+
+type logConfig struct {
+	chainFields bool
+	collision   Collision
+	members     bool
+}
+
+// WithChainFields makes [Log] pass fields of the whole error chain, collected by
+// [ChainFields] with given collision policy, instead of the fields of the
+// outermost Err only.
+func WithChainFields(c Collision) LogOption {
+	return func(cfg *logConfig) {
+		cfg.chainFields = true
+		cfg.collision = c
+	}
+}
+
+// WithMembers makes [Log] log every error aggregated by [Aggregate] as a
+// separate entry, with the aggregate reason as the message and the aggregated
+// error as the error. Errors that are not aggregates are logged as usual.
+func WithMembers() LogOption {
+	return func(cfg *logConfig) {
+		cfg.members = true
+	}
+}
+
 // Log logs the provided error using the given ErrorLogger function.
 //
 // If err is nil, Log does nothing. If err is of type Err, its reason is used as the log message,
 // the wrapped error is passed as the error, and its fields are passed as log fields.
-// For other error types, err.Error() is used as the message and nil is passed as the error.
-func Log(err error, f ErrorLogger) {
+// For other error types, err.Error() is used as the message and the outermost Err wrapped by
+// err, if any, is passed as the error, otherwise nil is passed.
+//
+// In case the Err chain has a stack trace (see [StackOf]), which is not carried by the wrapped
+// error, the error passed to the logger is made to carry it, so the logger maps it the same way
+// as for errors logged directly, e.g. with its stack trace mapper. In case there is no wrapped
+// error, the error carrying the stack trace has the reason of err as its message.
+//
+// Fields of the whole error chain are logged with [WithChainFields] option,
+// including errors of other types wrapping an Err.
+//
+// All errors aggregated by [Aggregate] are passed to the logger together as the
+// wrapped error, or logged one by one with [WithMembers] option.
+func Log(err error, f ErrorLogger, opts ...LogOption) {
 	if err == nil {
 		return
 	}
 
+	var cfg logConfig
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	// We're not interested in wrapped error, therefore we're only typecasting it.
 	if e, ok := err.(*Err); ok { //nolint:errorlint
+		if cfg.members && e.isAggregate() {
+			for _, member := range e.errs[1:] {
+				// Each member is logged as if it was the only wrapped error.
+				single := &Err{
+					errs:   []error{e.errs[0], member},
+					fields: e.fields,
+					stack:  e.stack,
+					kind:   e.kind,
+				}
+
+				logErr(single, member, f, &cfg)
+			}
+
+			return
+		}
+
 		var wrapped error
-		if len(e.errs) > 1 {
+
+		switch {
+		case e.isAggregate():
+			wrapped = members(e.errs[1:])
+		case len(e.errs) > 1:
 			wrapped = e.errs[1]
 		}
 
-		f(e.Reason(), wrapped, e.fields...)
+		logErr(e, wrapped, f, &cfg)
 
 		return
 	}
 
-	f(err.Error(), nil)
+	logOther(err, f, &cfg)
+}
+
+// logOther logs err of type other than Err. Errors wrapping an Err are logged
+// as is, while the Err is passed to the logger as the error, so its stack trace
+// is not lost.
+func logOther(err error, f ErrorLogger, cfg *logConfig) {
+	var fs fields.List
+
+	if cfg.chainFields {
+		fs = ChainFields(err, cfg.collision)
+	}
+
+	var wrapped *Err
+	if errors.As(err, &wrapped) {
+		f(err.Error(), wrapped, fs...)
+
+		return
+	}
+
+	f(err.Error(), nil, fs...)
+}
+
+// logErr logs e, passing wrapped to the logger as the error.
+func logErr(e *Err, wrapped error, f ErrorLogger, cfg *logConfig) {
+	fs := e.fields
+
+	if cfg.chainFields {
+		fs = ChainFields(e, cfg.collision)
+	}
+
+	f(e.Reason(), withStackOf(e, wrapped), fs...)
+}
+
+// withStackOf returns wrapped making it carry the stack trace of e chain, in
+// case it does not carry one already. In case wrapped is nil the reason of e is
+// used as the error carrying the stack trace.
+func withStackOf(e *Err, wrapped error) error {
+	st := StackOf(e)
+	if st == nil || StackOf(wrapped) != nil {
+		return wrapped
+	}
+
+	if wrapped == nil {
+		wrapped = e.errs[0]
+	}
+
+	return &Err{
+		errs:   []error{wrapped},
+		fields: nil,
+		stack:  st,
+		kind:   KindUnknown,
+	}
 }
@@ -0,0 +1,64 @@
+package e
+
+import (
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/stacktrace"
+)
+
+// ErrPanic is the error all errors produced from recovered panics are derived
+// from, see [Recover].
+var ErrPanic = New("panic recovered")
+
+// Recover converts a recovered panic into an Err and stores it in err. It must
+// be deferred directly, otherwise the panic is not recovered:
+//
+//	func handle() (err error) {
+//		defer e.Recover(&err)
+//
+//		// ...
+//	}
+//
+// Produced Err is derived from [ErrPanic] and carries the stack trace of the
+// goroutine at the moment of panic, regardless of [SetCaptureStack] setting.
+// Errors passed to panic, such as ones produced by must package, are wrapped,
+// so that they are matched by [errors.Is] and [errors.As]. Other panic values
+// are attached as "panic" field. In case there was no panic, err is left intact.
+func Recover(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	// skip Recover and the runtime frame of panic.
+	const skip = 2
+
+	*err = fromPanic(r, stacktrace.CaptureStack(skip, stackDepth))
+}
+
+// Safe calls fn, returning its error, or an error produced from panic raised
+// by fn, see [Recover].
+func Safe(fn func() error) (err error) {
+	defer Recover(&err)
+
+	return fn()
+}
+
+func fromPanic(r any, st *stacktrace.Stack) *Err {
+	var (
+		errs = []error{ErrPanic}
+		fs   fields.List
+	)
+
+	if rErr, ok := r.(error); ok {
+		errs = append(errs, rErr)
+	} else {
+		fs = fields.List{fields.F("panic", r)}
+	}
+
+	return &Err{
+		errs:   errs,
+		fields: fs,
+		stack:  st,
+		kind:   KindUnknown,
+	}
+}
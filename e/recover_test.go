@@ -0,0 +1,97 @@
+package e_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/must"
+)
+
+func panicking(v any) (err error) {
+	defer e.Recover(&err)
+
+	panic(v)
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	errBoom := e.New("boom")
+
+	t.Run("error value", func(t *testing.T) {
+		t.Parallel()
+
+		err := panicking(errBoom.WithField("foo", "bar"))
+
+		require.ErrorIs(t, err, e.ErrPanic)
+		require.ErrorIs(t, err, errBoom)
+		assert.Equal(t, "panic recovered: boom (foo=bar)", err.Error())
+		assert.Equal(t, "dev.gaijin.team/go/golib/e_test.panicking", firstFrame(t, e.StackOf(err)).Function)
+	})
+
+	t.Run("non-error value", func(t *testing.T) {
+		t.Parallel()
+
+		err := panicking(42)
+
+		require.ErrorIs(t, err, e.ErrPanic)
+		assert.Equal(t, "panic recovered (panic=42)", err.Error())
+		assert.Equal(t, fields.List{fields.F("panic", 42)}, e.ChainFields(err, e.CollisionKeepAll))
+		assert.Equal(t, "dev.gaijin.team/go/golib/e_test.panicking", firstFrame(t, e.StackOf(err)).Function)
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		t.Parallel()
+
+		err := e.Safe(func() error {
+			var m map[string]int
+			m["key"] = 1
+
+			return nil
+		})
+
+		var rErr runtime.Error
+		require.ErrorAs(t, err, &rErr)
+		require.ErrorIs(t, err, e.ErrPanic)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		t.Parallel()
+
+		var pErr *runtime.PanicNilError
+		require.ErrorAs(t, panicking(nil), &pErr)
+	})
+
+	t.Run("must round trip", func(t *testing.T) {
+		t.Parallel()
+
+		err := e.Safe(func() error {
+			must.NoErr(errBoom)
+
+			return nil
+		})
+
+		require.ErrorIs(t, err, errBoom)
+		assert.Equal(t, "panic recovered: must.NoErr assertion failed: boom", err.Error())
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, e.Safe(func() error { return nil }))
+		require.ErrorIs(t, e.Safe(func() error { return errBoom }), errBoom)
+
+		err := error(errBoom)
+
+		func() {
+			defer e.Recover(&err)
+		}()
+
+		assert.Same(t, errBoom, err)
+	})
+}
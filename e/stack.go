@@ -0,0 +1,82 @@
+package e
+
+import (
+	"sync/atomic"
+
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/stacktrace"
+)
+
+// stackDepth is the maximum number of frames captured for error stack trace.
+const stackDepth = 32
+
+//nolint:gochecknoglobals
+var captureStack atomic.Bool
+
+// SetCaptureStack enables or disables automatic stack trace capture for all
+// errors created by [New], [NewFrom], [From] and [Err.Wrap]. It is disabled by
+// default, since capturing the stack trace is more than an order of magnitude
+// slower than creating an error itself.
+//
+// Errors created before the capture is enabled, such as package-level sentinel
+// errors, have no stack trace. To capture the stack trace of a single error
+// regardless of this setting use [Err.WithStack].
+func SetCaptureStack(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+// newErr creates a new Err capturing the stack trace in case it is enabled. It
+// must only be called directly by exported functions, since the caller of the
+// latter is expected to be the first frame of the stack trace.
+func newErr(errs []error, f fields.List) *Err {
+	err := &Err{
+		errs:   errs,
+		fields: f,
+		stack:  nil,
+		kind:   KindUnknown,
+	}
+
+	if captureStack.Load() {
+		const skip = 2 // skip newErr and the exported function that called it
+
+		err.stack = stacktrace.CaptureStack(skip, stackDepth)
+	}
+
+	return err
+}
+
+// WithStack returns a new Err with the same error and the stack trace captured
+// at the point of the call, regardless of [SetCaptureStack] setting.
+func (e *Err) WithStack() *Err {
+	return &Err{
+		errs:   []error{e},
+		fields: nil,
+		stack:  stacktrace.CaptureStack(1, stackDepth),
+		kind:   KindUnknown,
+	}
+}
+
+// Stack returns the stack trace captured on Err creation, or nil if it was not
+// captured. Use [StackOf] to get the stack trace of the error origin.
+func (e *Err) Stack() *stacktrace.Stack {
+	if e == nil {
+		return nil
+	}
+
+	return e.stack
+}
+
+// StackOf returns the deepest stack trace found in err chain, which is the one
+// captured closest to the error origin. Returns nil if none of errors in chain
+// has stack trace.
+func StackOf(err error) *stacktrace.Stack {
+	var stack *stacktrace.Stack
+
+	walk(err, 0, func(ee *Err, _ int) {
+		if ee.stack != nil {
+			stack = ee.stack
+		}
+	})
+
+	return stack
+}
@@ -0,0 +1,144 @@
+package e_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/logger"
+	"dev.gaijin.team/go/golib/logger/bufferadapter"
+	"dev.gaijin.team/go/golib/stacktrace"
+)
+
+func firstFrame(t *testing.T, st *stacktrace.Stack) stacktrace.Frame {
+	t.Helper()
+
+	require.NotNil(t, st)
+
+	for _, f := range st.Frames() {
+		return f
+	}
+
+	t.Fatal("empty stack trace")
+
+	return stacktrace.Frame{}
+}
+
+func enableCaptureStack(t *testing.T) {
+	t.Helper()
+
+	e.SetCaptureStack(true)
+	t.Cleanup(func() { e.SetCaptureStack(false) })
+}
+
+func newInner() *e.Err {
+	return e.New("inner")
+}
+
+//nolint:paralleltest // modifies package-level capture setting
+func TestSetCaptureStack(t *testing.T) {
+	assert.Nil(t, e.New("err").Stack())
+
+	enableCaptureStack(t)
+
+	const fn = "dev.gaijin.team/go/golib/e_test.TestSetCaptureStack"
+
+	errBase := e.New("base")
+
+	for name, err := range map[string]*e.Err{
+		"New":          e.New("err"),
+		"NewFrom":      e.NewFrom("err", errBase),
+		"NewFrom(nil)": e.NewFrom("err", nil),
+		"From":         e.From(errors.New("err")),       //nolint:err113
+		"Wrap":         errBase.Wrap(errors.New("err")), //nolint:err113
+	} {
+		assert.Equal(t, fn, firstFrame(t, err.Stack()).Function, name)
+	}
+
+	assert.Nil(t, errBase.WithField("foo", "bar").Stack())
+	assert.Same(t, errBase.Stack(), errBase.Clone().Stack())
+}
+
+func TestErr_WithStack(t *testing.T) {
+	t.Parallel()
+
+	base := e.New("err", fields.F("foo", "bar"))
+	err := base.WithStack()
+
+	assert.Nil(t, base.Stack())
+	assert.Equal(t, "dev.gaijin.team/go/golib/e_test.TestErr_WithStack", firstFrame(t, err.Stack()).Function)
+	assert.Equal(t, base.Error(), err.Error())
+	assert.ErrorIs(t, err, base)
+	assert.Nil(t, (*e.Err)(nil).Stack())
+}
+
+func TestStackOf(t *testing.T) {
+	t.Parallel()
+
+	inner := newInner().WithStack()
+	outer := e.New("outer").WithStack()
+
+	assert.Nil(t, e.StackOf(nil))
+	assert.Nil(t, e.StackOf(errors.New("err"))) //nolint:err113
+	assert.Nil(t, e.StackOf(e.New("err")))
+
+	assert.Same(t, outer.Stack(), e.StackOf(outer))
+	assert.Same(t, inner.Stack(), e.StackOf(outer.Wrap(inner)))
+	assert.Same(t, inner.Stack(), e.StackOf(fmt.Errorf("context: %w", outer.Wrap(inner))))
+	assert.Same(t, inner.Stack(), e.StackOf(errors.Join(errors.New("other"), inner)))               //nolint:err113
+	assert.Same(t, outer.Stack(), e.StackOf(outer.Wrap(errors.New("err")).WithField("foo", "bar"))) //nolint:err113
+}
+
+func TestLog_Stack(t *testing.T) {
+	t.Parallel()
+
+	adapter, buff := bufferadapter.New()
+	log := logger.New(adapter, logger.WithStackTraceMapper(func(st *stacktrace.Stack) fields.Field {
+		return fields.F("stack", firstFrame(t, st).Function)
+	}))
+
+	inner := newInner().WithStack()
+
+	// stack trace of wrapped error is carried by it.
+	e.Log(e.New("outer").Wrap(inner), log.Error)
+
+	// while stack trace of error itself is passed along with the wrapped error
+	// or its reason.
+	withStack := e.New("outer").WithStack()
+	e.Log(withStack, log.Error)
+	e.Log(e.NewFrom("outer", errors.New("cause")).WithStack(), log.Error) //nolint:err113
+
+	// errors wrapping an Err pass it to the logger.
+	e.Log(fmt.Errorf("context: %w", withStack), log.Error)
+
+	frame := firstFrame(t, withStack.Stack()).Function
+
+	assert.Equal(t, []bufferadapter.LogEntry{
+		{
+			Level:  logger.LevelError,
+			Msg:    "outer",
+			Fields: fields.List{fields.F("error", "inner"), fields.F("stack", frame)},
+		},
+		{
+			Level:  logger.LevelError,
+			Msg:    "outer",
+			Fields: fields.List{fields.F("error", "outer"), fields.F("stack", frame)},
+		},
+		{
+			Level:  logger.LevelError,
+			Msg:    "outer: cause",
+			Fields: fields.List{fields.F("error", "outer: cause"), fields.F("stack", frame)},
+		},
+		{
+			Level:  logger.LevelError,
+			Msg:    "context: outer",
+			Fields: fields.List{fields.F("error", "outer"), fields.F("stack", frame)},
+		},
+	}, buff.GetAll())
+	assert.Equal(t, "dev.gaijin.team/go/golib/e_test.TestLog_Stack", frame)
+}
@@ -2,6 +2,8 @@ package fields
 
 import (
 	"iter"
+	"maps"
+	"slices"
 	"strings"
 )
 
@@ -19,17 +21,17 @@ type Dict map[string]any
 //	d.Add(fields.F("baz", 42), fields.F("foo", "qux")) // d["foo"] == "qux"
 func (d Dict) Add(fields ...Field) {
 	for _, f := range fields {
-		d[f.K] = f.V
+		d[f.K] = f.Value()
 	}
 }
 
-// ToList converts the Dict to a List, with order unspecified.
+// ToList converts the Dict to a List, sorted by keys.
 // Each key-value pair becomes a Field in the resulting List.
 func (d Dict) ToList() List {
 	s := make(List, 0, len(d))
 
-	for k, v := range d {
-		s = append(s, Field{k, v})
+	for k, v := range d.Sorted() {
+		s = append(s, F(k, v))
 	}
 
 	return s
@@ -52,14 +54,31 @@ func (d Dict) All() iter.Seq2[string, any] {
 	}
 }
 
+// Sorted returns an iterator over all key-value pairs in the Dict, sorted by keys.
+//
+// Example:
+//
+//	for k, v := range d.Sorted() {
+//	    fmt.Println(k, v)
+//	}
+func (d Dict) Sorted() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for _, k := range slices.Sorted(maps.Keys(d)) {
+			if !yield(k, d[k]) {
+				return
+			}
+		}
+	}
+}
+
 // WriteTo writes the Dict as a string in the format "(key1=val1, key2=val2)" to the provided builder.
-// If the Dict is empty, nothing is written. The order of fields is unspecified.
+// If the Dict is empty, nothing is written. Fields are sorted by keys.
 func (d Dict) WriteTo(b *strings.Builder) {
-	WriteTo(b, d.All())
+	WriteTo(b, d.Sorted())
 }
 
 // String returns the Dict as a string in the format "(key1=val1, key2=val2)".
-// Returns an empty string if the Dict is empty. The order of fields is unspecified.
+// Returns an empty string if the Dict is empty. Fields are sorted by keys.
 func (d Dict) String() string {
 	b := strings.Builder{}
 
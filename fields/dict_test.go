@@ -28,7 +28,12 @@ func TestDict(t *testing.T) {
 
 		d := fields.Dict{"foo": "bar", "baz": "qux"}
 
-		require.ElementsMatch(t, fields.List{{"foo", "bar"}, {"baz", "qux"}}, d.ToList())
+		require.Equal(t, fields.List{fields.F("baz", "qux"), fields.F("foo", "bar")}, d.ToList())
+
+		d = fields.Dict{"e": 5, "c": 3, "a": 1, "d": 4, "b": 2}
+		for range 10 {
+			require.Equal(t, "(a=1, b=2, c=3, d=4, e=5)", d.ToList().String())
+		}
 	})
 
 	t.Run("String", func(t *testing.T) {
@@ -44,6 +49,30 @@ func TestDict(t *testing.T) {
 		require.ElementsMatch(t, []string{"foo=bar", "baz=qux"}, collectionStringToKVElements(d.String()))
 	})
 
+	t.Run("String is sorted", func(t *testing.T) {
+		t.Parallel()
+
+		d := fields.Dict{"foo": "bar", "baz": "qux", "abc": 1, "zzz": nil}
+
+		for range 10 {
+			require.Equal(t, "(abc=1, baz=qux, foo=bar, zzz=<nil>)", d.String())
+		}
+	})
+
+	t.Run("Sorted early exit", func(t *testing.T) {
+		t.Parallel()
+
+		d := fields.Dict{"foo": "bar", "baz": "qux"}
+
+		var seen []string
+		for k := range d.Sorted() {
+			seen = append(seen, k)
+			break // stop after first
+		}
+
+		require.Equal(t, []string{"baz"}, seen)
+	})
+
 	t.Run("All early exit", func(t *testing.T) {
 		t.Parallel()
 
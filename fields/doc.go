@@ -1,15 +1,39 @@
 // Package fields provides types and functions to work with key-value pairs.
 //
-// The package offers three primary abstractions:
+// The package offers four primary abstractions:
 //
 //   - Field: A key-value pair where the key is a string and the value can be any type.
 //   - Dict: A map-based collection of unique fields, providing efficient key-based lookup.
 //   - List: An ordered collection of fields that preserves insertion order.
+//   - OrderedDict: A collection of unique fields that preserves insertion order.
 //
 // Fields can be created using the F constructor, and both Dict and List provide
 // conversion methods between the two collection types. All types implement String()
 // for consistent string representation.
 //
+// Besides F, fields can be created with typed constructors, such as String, Int,
+// Bool, Duration, Time or Err, which keep values without boxing them into
+// interface. Logger adapters emit such fields as native values of underlying
+// loggers, and Field.Value returns the value of a field of any type.
+//
+// Fields are nested with Group, which holds a sub-List, and Namespace, which
+// nests all fields following it, including the ones emitted by a child logger
+// it is attached to:
+//
+//	fields.Group("request", fields.F("method", "GET")) // "request=(method=GET)"
+//
+// Values of fields created with Lazy are computed only when they are actually
+// written, so that expensive values cost nothing for filtered out log entries.
+//
+// Values of fields created with Sensitive, such as tokens or passwords, are
+// rendered as RedactedValue everywhere, including JSON and slog, while the raw
+// value stays available via Raw.
+//
+// String representation of lists and dicts is meant for humans and does not
+// escape anything. For machine-readable output use List.EncodeLogfmt and
+// List.EncodeJSON, which quote and escape values properly, and ParseLogfmt and
+// ParseJSON to read such output back into a List.
+//
 // Example usage:
 //
 //	// Create fields
@@ -24,9 +48,9 @@
 //	// Working with a Dict (unique key collection)
 //	dict := fields.Dict{}
 //	dict.Add(f1, f2, fields.F("status", "updated")) // overwrites "status"
-//	fmt.Println(dict) // "(status=updated, code=200)" (order may vary)
+//	fmt.Println(dict) // "(code=200, status=updated)" (sorted by keys)
 //
 //	// Converting between types
-//	list2 := dict.ToList() // order unspecified
+//	list2 := dict.ToList() // sorted by keys
 //	dict2 := list.ToDict() // last occurrence of each key wins
 package fields
@@ -7,11 +7,27 @@ import (
 )
 
 // Field represents a key-value pair, where the key is a string and the value can be any type.
+//
+// Fields created with typed constructors, such as [Int] or [String], keep their
+// values in a compact form without boxing them into interface. Therefore, V
+// holds the value only for fields of [TypeAny] and [TypeError], created with
+// [F], [Sensitive] and [Err], while for other types it is either nil or holds
+// the internal representation of the value, such as the function of [Lazy]
+// field. Use [Field.Value] to get the value of any field, code reading V
+// directly, such as logger adapters, must check [Field.Type] first.
+//
+// Since Field has unexported members, it can no longer be created with unkeyed
+// composite literal, such as Field{"key", value}. Use [F] or keyed literal
+// Field{K: "key", V: value} instead, which creates a field of [TypeAny].
 type Field struct {
 	// Key of the field
 	K string
 	// Value of the field
 	V any
+
+	typ Type
+	num uint64
+	str string
 }
 
 // F creates a new Field with the given key and value.
@@ -20,14 +36,18 @@ type Field struct {
 //
 //	f := fields.F("user", "alice")
 func F(key string, value any) Field {
-	return Field{K: key, V: value}
+	return Field{K: key, V: value, typ: TypeAny, num: 0, str: ""}
 }
 
 // writeKVTo writes a key-value pair to the given builder in the format "key=value".
 func writeKVTo(b *strings.Builder, key string, value any) {
 	b.WriteString(key)
 	b.WriteRune('=')
+	writeKVValueTo(b, value)
+}
 
+// writeKVValueTo writes a value to the given builder, the same way [writeKVTo] does.
+func writeKVValueTo(b *strings.Builder, value any) {
 	switch val := value.(type) {
 	case string:
 		b.WriteString(val)
@@ -45,7 +65,24 @@ func writeKVTo(b *strings.Builder, key string, value any) {
 
 // WriteTo writes the Field as a string in the format "key=value" to the provided builder.
 func (f Field) WriteTo(b *strings.Builder) {
-	writeKVTo(b, f.K, f.V)
+	if f.typ == TypeAny {
+		writeKVTo(b, f.K, f.V)
+
+		return
+	}
+
+	b.WriteString(f.K)
+	b.WriteRune('=')
+	f.writeValueTo(b)
+}
+
+// ValueString returns the value of the Field as a string, the same as written by
+// [Field.WriteTo].
+func (f Field) ValueString() string {
+	b := &strings.Builder{}
+	f.writeValueTo(b)
+
+	return b.String()
 }
 
 // String returns the Field as a string in the format "key=value".
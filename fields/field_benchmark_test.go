@@ -3,6 +3,9 @@ package fields_test
 import (
 	"strconv"
 	"testing"
+	"time"
+
+	"dev.gaijin.team/go/golib/fields"
 )
 
 // goos: windows
@@ -108,3 +111,47 @@ func benchStructConstructor(b *testing.B, size int) {
 		b.Logf("%d", len(arr))
 	})
 }
+
+// goos: linux
+// goarch: amd64
+// pkg: dev.gaijin.team/go/golib/fields
+// cpu: Intel(R) Xeon(R) Processor
+// Benchmark_Constructors/F/int         	36071812	        35.92 ns/op	       8 B/op	       1 allocs/op
+// Benchmark_Constructors/Int           	52179093	        24.68 ns/op	       0 B/op	       0 allocs/op
+// Benchmark_Constructors/F/string      	15438168	        78.92 ns/op	      23 B/op	       2 allocs/op
+// Benchmark_Constructors/String        	22949464	        46.49 ns/op	       7 B/op	       1 allocs/op
+// Benchmark_Constructors/F/duration    	41248405	        32.64 ns/op	       8 B/op	       1 allocs/op
+// Benchmark_Constructors/Duration      	53406186	        23.13 ns/op	       0 B/op	       0 allocs/op
+// Benchmark_Constructors/F/time        	20732028	        76.53 ns/op	      24 B/op	       1 allocs/op
+// Benchmark_Constructors/Time          	37204237	        32.21 ns/op	       0 B/op	       0 allocs/op
+// PASS.
+//
+// The only allocation of String is made by strconv.Itoa.
+func Benchmark_Constructors(b *testing.B) {
+	ts := time.Now()
+
+	benchConstructor(b, "F/int", func(n int) fields.Field { return fields.F("key", n) })
+	benchConstructor(b, "Int", func(n int) fields.Field { return fields.Int("key", n) })
+	benchConstructor(b, "F/string", func(n int) fields.Field { return fields.F("key", strconv.Itoa(n)) })
+	benchConstructor(b, "String", func(n int) fields.Field { return fields.String("key", strconv.Itoa(n)) })
+	benchConstructor(b, "F/duration", func(n int) fields.Field { return fields.F("key", time.Duration(n)) })
+	benchConstructor(b, "Duration", func(n int) fields.Field { return fields.Duration("key", time.Duration(n)) })
+	benchConstructor(b, "F/time", func(n int) fields.Field { return fields.F("key", ts.Add(time.Duration(n))) })
+	benchConstructor(b, "Time", func(n int) fields.Field { return fields.Time("key", ts.Add(time.Duration(n))) })
+}
+
+//nolint:gochecknoglobals
+var fieldSink fields.Field
+
+func benchConstructor(b *testing.B, name string, fn func(n int) fields.Field) {
+	b.Helper()
+
+	b.Run(name, func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := range b.N {
+			// values below 256 are boxed without allocation, skip them.
+			fieldSink = fn(i + 256)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package fields
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// Group creates a new Field holding nested fields under the given key. It is
+// written as "key=(k1=v1, k2=v2)" and emitted by logger adapters as a nested
+// object of underlying loggers, or as keys prefixed with "key." if nesting is
+// not supported.
+//
+// Example:
+//
+//	f := fields.Group("request", fields.F("method", "GET"), fields.F("path", "/"))
+//	f.String() // "request=(method=GET, path=/)"
+func Group(key string, fs ...Field) Field {
+	return Field{K: key, V: List(fs), typ: TypeGroup, num: 0, str: ""}
+}
+
+// Namespace creates a new Field, which nests all fields following it under the
+// given key. Within a List, these are the fields placed after the namespace,
+// while being attached to a logger with logger.WithFields it namespaces all the
+// fields emitted by the child logger.
+//
+// Example:
+//
+//	l := fields.List{fields.F("a", 1), fields.Namespace("ns"), fields.F("b", 2)}
+//	l.String() // "(a=1, ns=(b=2))"
+func Namespace(key string) Field {
+	return Field{K: key, V: nil, typ: TypeNamespace, num: 0, str: ""}
+}
+
+// GroupValue returns the nested fields of the Field of [TypeGroup].
+func (f Field) GroupValue() List {
+	l, _ := f.V.(List)
+
+	return l
+}
+
+// Attrs returns the List as a list of [slog.Attr], nesting fields placed after
+// a namespace into the group named after it.
+func (l List) Attrs() []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(l))
+
+	for i, f := range l {
+		if f.typ == TypeNamespace {
+			return append(attrs, slog.Attr{Key: f.K, Value: slog.GroupValue(l[i+1:].Attrs()...)})
+		}
+
+		attrs = append(attrs, f.Attr())
+	}
+
+	return attrs
+}
+
+// Flatten returns the List with fields of groups and namespaces moved to the top
+// level, their keys prefixed with the group or namespace keys separated by dot:
+//
+//	l := fields.List{fields.Group("request", fields.F("method", "GET"))}
+//	l.Flatten() // (request.method=GET)
+func (l List) Flatten() List {
+	return l.flattenTo(make(List, 0, len(l)), "")
+}
+
+func (l List) flattenTo(dst List, prefix string) List {
+	for i, f := range l {
+		switch f.typ { //nolint:exhaustive
+		case TypeGroup:
+			dst = f.GroupValue().flattenTo(dst, prefix+f.K+".")
+		case TypeNamespace:
+			return l[i+1:].flattenTo(dst, prefix+f.K+".")
+		default:
+			f.K = prefix + f.K
+			dst = append(dst, f)
+		}
+	}
+
+	return dst
+}
+
+// writeListTo writes fields separated by [CollectionSep], without enclosing
+// parentheses, nesting fields placed after a namespace.
+func writeListTo(b *strings.Builder, l List) {
+	for i, f := range l {
+		if i > 0 {
+			b.WriteString(CollectionSep)
+		}
+
+		if f.typ == TypeNamespace {
+			b.WriteString(f.K)
+			b.WriteString("=(")
+			writeListTo(b, l[i+1:])
+			b.WriteString(")")
+
+			return
+		}
+
+		f.WriteTo(b)
+	}
+}
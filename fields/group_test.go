@@ -0,0 +1,53 @@
+package fields_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	g := fields.Group("request", fields.F("method", "GET"), fields.Int("size", 42))
+
+	assert.Equal(t, fields.TypeGroup, g.Type())
+	assert.Equal(t, fields.List{fields.F("method", "GET"), fields.Int("size", 42)}, g.GroupValue())
+	assert.Equal(t, g.GroupValue(), g.Value())
+	assert.Equal(t, "request=(method=GET, size=42)", g.String())
+	assert.Equal(t, "request=()", fields.Group("request").String())
+	assert.Equal(t, "(id=1, request=(method=GET, size=42))", fields.List{fields.F("id", 1), g}.String())
+}
+
+func TestNamespace(t *testing.T) {
+	t.Parallel()
+
+	l := fields.List{
+		fields.F("a", 1),
+		fields.Namespace("ns"),
+		fields.F("b", 2),
+		fields.Group("g", fields.F("c", 3), fields.Namespace("inner"), fields.F("d", 4)),
+		fields.Namespace("deeper"),
+		fields.F("e", 5),
+	}
+
+	assert.Equal(t, fields.TypeNamespace, l[1].Type())
+	assert.Nil(t, l[1].Value())
+	assert.Equal(t, "(a=1, ns=(b=2, g=(c=3, inner=(d=4)), deeper=(e=5)))", l.String())
+
+	assert.Equal(t, fields.List{
+		fields.F("a", 1),
+		fields.F("ns.b", 2),
+		fields.F("ns.g.c", 3),
+		fields.F("ns.g.inner.d", 4),
+		fields.F("ns.deeper.e", 5),
+	}, l.Flatten())
+
+	b := &bytes.Buffer{}
+	slog.New(slog.NewJSONHandler(b, nil)).LogAttrs(t.Context(), slog.LevelInfo, "msg", l.Attrs()...)
+	assert.Contains(t, b.String(), `"a":1,"ns":{"b":2,"g":{"c":3,"inner":{"d":4}},"deeper":{"e":5}}}`)
+}
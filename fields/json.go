@@ -0,0 +1,160 @@
+package fields
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidJSON is returned by [ParseJSON] for malformed input.
+var ErrInvalidJSON = errors.New("invalid fields JSON")
+
+// EncodeJSON writes the List to w as a JSON object, keeping the fields order.
+// Groups and fields placed after a namespace are written as nested objects.
+// Errors are written as their text, and values that cannot be marshalled are
+// written as their string representation.
+//
+// In case of duplicate keys, all of them are written.
+func (l List) EncodeJSON(w io.Writer) error {
+	b := &bytes.Buffer{}
+	writeJSONObject(b, l)
+
+	_, err := w.Write(b.Bytes())
+
+	return err //nolint:wrapcheck
+}
+
+// EncodeJSON writes the Dict to w as a JSON object, same as [List.EncodeJSON]
+// does. Fields are sorted by keys.
+func (d Dict) EncodeJSON(w io.Writer) error {
+	return d.ToList().EncodeJSON(w)
+}
+
+func writeJSONObject(b *bytes.Buffer, l List) {
+	b.WriteByte('{')
+
+	for i, f := range l {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		writeJSONValue(b, f.K)
+		b.WriteByte(':')
+
+		switch f.typ { //nolint:exhaustive
+		case TypeGroup:
+			writeJSONObject(b, f.GroupValue())
+		case TypeNamespace:
+			writeJSONObject(b, l[i+1:])
+			b.WriteByte('}')
+
+			return
+		default:
+			writeJSONValue(b, f.Value())
+		}
+	}
+
+	b.WriteByte('}')
+}
+
+func writeJSONValue(b *bytes.Buffer, v any) {
+	if err, ok := v.(error); ok {
+		if _, ok = v.(json.Marshaler); !ok {
+			v = err.Error()
+		}
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%v", v))
+	}
+
+	b.Write(data)
+}
+
+// ParseJSON parses a JSON object, such as written by [List.EncodeJSON], into a
+// List, keeping the fields order. Nested objects are parsed as groups, see
+// [Group]. Numbers are parsed as [json.Number], arrays as []any and objects
+// within arrays as map[string]any.
+func ParseJSON(data []byte) (List, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	list, err := readJSONObject(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: unexpected data after object", ErrInvalidJSON)
+	}
+
+	return list, nil
+}
+
+func readJSONObject(dec *json.Decoder) (List, error) {
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var list List
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+		}
+
+		key, _ := tok.(string)
+
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+		}
+
+		f, err := parseJSONField(key, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, f)
+	}
+
+	return list, expectJSONDelim(dec, '}')
+}
+
+func parseJSONField(key string, raw json.RawMessage) (Field, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	if raw[0] == '{' {
+		group, err := readJSONObject(dec)
+		if err != nil {
+			return Field{}, err //nolint:exhaustruct
+		}
+
+		return Group(key, group...), nil
+	}
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return Field{}, fmt.Errorf("%w: %w", ErrInvalidJSON, err) //nolint:exhaustruct
+	}
+
+	return F(key, v), nil
+}
+
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+
+	if tok != delim {
+		return fmt.Errorf("%w: expected %v, got %v", ErrInvalidJSON, delim, tok)
+	}
+
+	return nil
+}
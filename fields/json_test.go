@@ -0,0 +1,126 @@
+package fields_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func TestList_EncodeJSON(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		in       fields.List
+		expected string
+	}{
+		{
+			name:     "empty",
+			in:       nil,
+			expected: `{}`,
+		},
+		{
+			name: "values keep order",
+			in: fields.List{
+				fields.F("z", "last?"),
+				fields.Int("a", 1),
+				fields.F("nil", nil),
+				fields.F("list", []int{1, 2}),
+				fields.Bool("ok", true),
+			},
+			expected: `{"z":"last?","a":1,"nil":null,"list":[1,2],"ok":true}`,
+		},
+		{
+			name:     "error value",
+			in:       fields.List{fields.F("err", errors.New("boom"))}, //nolint:err113
+			expected: `{"err":"boom"}`,
+		},
+		{
+			name: "group and namespace",
+			in: fields.List{
+				fields.Group("req", fields.F("method", "GET")),
+				fields.Namespace("db"),
+				fields.F("query", "SELECT 1"),
+			},
+			expected: `{"req":{"method":"GET"},"db":{"query":"SELECT 1"}}`,
+		},
+		{
+			name:     "sensitive value",
+			in:       fields.List{fields.Sensitive("token", "secret")},
+			expected: `{"token":"` + fields.RedactedValue + `"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		b := &bytes.Buffer{}
+
+		require.NoError(t, tc.in.EncodeJSON(b), tc.name)
+		assert.Equal(t, tc.expected, b.String(), tc.name)
+	}
+}
+
+func TestList_EncodeJSON_Unmarshallable(t *testing.T) {
+	t.Parallel()
+
+	b := &bytes.Buffer{}
+
+	require.NoError(t, fields.List{fields.F("fn", func() {}), fields.F("ok", 1)}.EncodeJSON(b))
+
+	list, err := fields.ParseJSON(b.Bytes())
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.IsType(t, "", list[0].Value(), "written as string representation")
+}
+
+func TestDict_EncodeJSON(t *testing.T) {
+	t.Parallel()
+
+	b := &bytes.Buffer{}
+
+	require.NoError(t, fields.Dict{"a": 1}.EncodeJSON(b))
+	assert.JSONEq(t, `{"a":1}`, b.String())
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	list, err := fields.ParseJSON([]byte(`{"z":"v","a":1.5,"n":null,"arr":[1,{"k":true}],"req":{"method":"GET","h":{}}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, fields.List{
+		fields.F("z", "v"),
+		fields.F("a", json.Number("1.5")),
+		fields.F("n", nil),
+		fields.F("arr", []any{json.Number("1"), map[string]any{"k": true}}),
+		fields.Group("req", fields.F("method", "GET"), fields.Group("h")),
+	}, list)
+
+	for _, in := range []string{``, `[]`, `{"a":}`, `{"a":1`, `{"a":1}{}`, `"str"`} {
+		_, err = fields.ParseJSON([]byte(in))
+
+		assert.ErrorIs(t, err, fields.ErrInvalidJSON, in)
+	}
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := fields.List{
+		fields.F("msg", "multi\nline, (value)"),
+		fields.Group("req", fields.F("method", "GET")),
+		fields.F("b", false),
+	}
+
+	b := &bytes.Buffer{}
+	require.NoError(t, in.EncodeJSON(b))
+
+	out, err := fields.ParseJSON(b.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+}
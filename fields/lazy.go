@@ -0,0 +1,52 @@
+package fields
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// Lazy creates a new Field, which value is computed by fn only when it is
+// actually needed: when the field is written, stringified or its value is
+// requested. Therefore, expensive values are not computed for log entries
+// filtered out by level:
+//
+//	log.Debug("request", fields.Lazy("dump", func() any { return dumpRequest(r) }))
+//
+// The fn is called at most once, even if the value is needed several times.
+// Logger adapters compute the value right before the entry is written, while
+// [Dict.Add] computes it immediately, since Dict stores plain values.
+func Lazy(key string, fn func() any) Field {
+	if fn == nil {
+		return F(key, nil)
+	}
+
+	return Field{K: key, V: sync.OnceValue(fn), typ: TypeLazy, num: 0, str: ""}
+}
+
+// Resolve returns the Field with its value computed in case it is created by
+// [Lazy], or the Field itself otherwise.
+func (f Field) Resolve() Field {
+	if f.typ != TypeLazy {
+		return f
+	}
+
+	return F(f.K, f.Value())
+}
+
+// lazyValue evaluates the value of lazy field.
+func (f Field) lazyValue() any {
+	fn, _ := f.V.(func() any)
+	if fn == nil {
+		return nil
+	}
+
+	return fn()
+}
+
+// lazyValuer defers computation of lazy field value until slog handler
+// resolves it.
+type lazyValuer func() any
+
+func (l lazyValuer) LogValue() slog.Value {
+	return slog.AnyValue(l())
+}
@@ -0,0 +1,59 @@
+package fields_test
+
+import (
+	"bytes"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func lazyCounter(value any) (func() any, *atomic.Int32) {
+	var calls atomic.Int32
+
+	return func() any {
+		calls.Add(1)
+
+		return value
+	}, &calls
+}
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	fn, calls := lazyCounter("dump")
+	f := fields.Lazy("key", fn)
+
+	assert.Equal(t, fields.TypeLazy, f.Type())
+	assert.Zero(t, calls.Load())
+
+	assert.Equal(t, "key=dump", f.String())
+	assert.Equal(t, "dump", f.Value())
+	assert.Equal(t, fields.F("key", "dump"), f.Resolve())
+	assert.Equal(t, "(key=dump)", fields.List{f}.String())
+	assert.Equal(t, int32(1), calls.Load())
+
+	assert.Equal(t, fields.F("key", 1), fields.F("key", 1).Resolve())
+	assert.Equal(t, fields.F("key", nil), fields.Lazy("key", nil))
+}
+
+func TestLazy_Attr(t *testing.T) {
+	t.Parallel()
+
+	fn, calls := lazyCounter(map[string]int{"a": 1})
+	attr := fields.Lazy("key", fn).Attr()
+
+	b := &bytes.Buffer{}
+	log := slog.New(slog.NewJSONHandler(b, &slog.HandlerOptions{Level: slog.LevelInfo})) //nolint:exhaustruct
+
+	log.LogAttrs(t.Context(), slog.LevelDebug, "filtered", attr)
+	assert.Zero(t, calls.Load())
+	assert.Empty(t, b.String())
+
+	log.LogAttrs(t.Context(), slog.LevelInfo, "written", attr)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Contains(t, b.String(), `"key":{"a":1}`)
+}
@@ -29,7 +29,7 @@ func (l List) ToDict() Dict {
 	d := make(Dict, len(l))
 
 	for i := range l {
-		d[l[i].K] = l[i].V
+		d[l[i].K] = l[i].Value()
 	}
 
 	return d
@@ -45,7 +45,7 @@ func (l List) ToDict() Dict {
 func (l List) All() iter.Seq2[string, any] {
 	return func(yield func(string, any) bool) {
 		for i := range len(l) {
-			if !yield(l[i].K, l[i].V) {
+			if !yield(l[i].K, l[i].Value()) {
 				return
 			}
 		}
@@ -55,7 +55,13 @@ func (l List) All() iter.Seq2[string, any] {
 // WriteTo writes the List as a string in the format "(key1=val1, key2=val2)" to the provided builder.
 // If the List is empty, nothing is written.
 func (l List) WriteTo(b *strings.Builder) {
-	WriteTo(b, l.All())
+	if len(l) == 0 {
+		return
+	}
+
+	b.WriteString("(")
+	writeListTo(b, l)
+	b.WriteString(")")
 }
 
 // String returns the List as a string in the format "(key1=val1, key2=val2)".
@@ -14,18 +14,18 @@ func TestList(t *testing.T) {
 	t.Run("Add", func(t *testing.T) {
 		t.Parallel()
 
-		l := fields.List{{"foo", "baz"}}
+		l := fields.List{fields.F("foo", "baz")}
 		l.Add(fields.F("foo", "bar"), fields.F("baz", "qux"))
-		require.Equal(t, fields.List{{"foo", "baz"}, {"foo", "bar"}, {"baz", "qux"}}, l)
+		require.Equal(t, fields.List{fields.F("foo", "baz"), fields.F("foo", "bar"), fields.F("baz", "qux")}, l)
 	})
 
 	t.Run("ToDict", func(t *testing.T) {
 		t.Parallel()
 
-		l := fields.List{{"foo", "bar"}, {"foo", "baz"}, {"baz", "qux"}}
+		l := fields.List{fields.F("foo", "bar"), fields.F("foo", "baz"), fields.F("baz", "qux")}
 		require.Equal(t, fields.Dict{"foo": "baz", "baz": "qux"}, l.ToDict())
 
-		l = fields.List{{"foo", "baz"}, {"foo", "bar"}, {"baz", "qux"}}
+		l = fields.List{fields.F("foo", "baz"), fields.F("foo", "bar"), fields.F("baz", "qux")}
 		require.Equal(t, fields.Dict{"foo": "bar", "baz": "qux"}, l.ToDict())
 	})
 
@@ -35,10 +35,10 @@ func TestList(t *testing.T) {
 		l := fields.List{}
 		require.Empty(t, l.String())
 
-		l = fields.List{{"foo", "bar"}}
+		l = fields.List{fields.F("foo", "bar")}
 		require.Equal(t, "(foo=bar)", l.String())
 
-		l = fields.List{{"foo", "bar"}, {"baz", "qux"}}
+		l = fields.List{fields.F("foo", "bar"), fields.F("baz", "qux")}
 		require.Equal(t, "(foo=bar, baz=qux)", l.String())
 	})
 
@@ -46,8 +46,8 @@ func TestList(t *testing.T) {
 		t.Parallel()
 
 		l := fields.List{
-			{"foo", "bar"},
-			{"baz", "qux"},
+			fields.F("foo", "bar"),
+			fields.F("baz", "qux"),
 		}
 
 		var seen []string
@@ -0,0 +1,229 @@
+package fields
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidLogfmt is returned by [ParseLogfmt] for malformed input.
+var ErrInvalidLogfmt = errors.New("invalid logfmt")
+
+// EncodeLogfmt writes the List to w as a single logfmt line without trailing
+// newline: key=value pairs separated by space.
+//
+// Values are rendered the same as by [List.WriteTo] and quoted with Go escaping
+// in case they are empty or contain spaces, quotes, equal signs or non-printable
+// characters. Nil values are written as null. Fields of groups and namespaces
+// are flattened, see [List.Flatten]. Characters not allowed in logfmt keys are
+// replaced with underscore.
+//
+//	fields.List{fields.F("msg", "hello world"), fields.F("id", 1)}.EncodeLogfmt(w)
+//	// msg="hello world" id=1
+func (l List) EncodeLogfmt(w io.Writer) error {
+	b := &strings.Builder{}
+
+	for i, f := range l.Flatten() {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+
+		writeLogfmtKey(b, f.K)
+		b.WriteByte('=')
+
+		if f.Value() == nil {
+			b.WriteString("null")
+
+			continue
+		}
+
+		writeLogfmtValue(b, f.ValueString())
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err //nolint:wrapcheck
+}
+
+// EncodeLogfmt writes the Dict to w as a single logfmt line, same as
+// [List.EncodeLogfmt] does. Fields are sorted by keys.
+func (d Dict) EncodeLogfmt(w io.Writer) error {
+	return d.ToList().EncodeLogfmt(w)
+}
+
+func writeLogfmtKey(b *strings.Builder, key string) {
+	if key == "" {
+		b.WriteByte('_')
+
+		return
+	}
+
+	for _, r := range key {
+		if !validLogfmtKeyRune(r) {
+			r = '_'
+		}
+
+		b.WriteRune(r)
+	}
+}
+
+func validLogfmtKeyRune(r rune) bool {
+	return r > ' ' && r != '=' && r != '"' && r != utf8.RuneError && unicode.IsPrint(r)
+}
+
+func writeLogfmtValue(b *strings.Builder, val string) {
+	if !needsLogfmtQuote(val) {
+		b.WriteString(val)
+
+		return
+	}
+
+	b.WriteString(strconv.Quote(val))
+}
+
+func needsLogfmtQuote(s string) bool {
+	if s == "" || s == "null" {
+		return true
+	}
+
+	for _, r := range s {
+		if r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseLogfmt parses a logfmt line, such as written by [List.EncodeLogfmt],
+// into a List, keeping the fields order. Values are parsed as strings, except
+// for unquoted null, which is parsed as nil, same as values of keys without
+// values.
+func ParseLogfmt(line string) (List, error) {
+	var list List
+
+	for i := 0; ; {
+		for i < len(line) && isLogfmtSpace(line[i]) {
+			i++
+		}
+
+		if i == len(line) {
+			return list, nil
+		}
+
+		key, err := parseLogfmtKey(line, i)
+		if err != nil {
+			return nil, err
+		}
+
+		i += len(key)
+
+		// key without value.
+		if i == len(line) || line[i] != '=' {
+			list = append(list, F(key, nil))
+
+			continue
+		}
+
+		i++
+
+		val, n, err := parseLogfmtValue(line[i:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: value of %q at %d: %w", ErrInvalidLogfmt, key, i, err)
+		}
+
+		i += n
+
+		list = append(list, F(key, val))
+	}
+}
+
+// parseLogfmtKey parses the key starting at offset i of the line.
+func parseLogfmtKey(line string, i int) (string, error) {
+	start := i
+
+	for i < len(line) && line[i] != '=' && !isLogfmtSpace(line[i]) {
+		if line[i] == '"' {
+			return "", fmt.Errorf("%w: unexpected quote at %d", ErrInvalidLogfmt, i)
+		}
+
+		i++
+	}
+
+	if i == start {
+		return "", fmt.Errorf("%w: empty key at %d", ErrInvalidLogfmt, i)
+	}
+
+	return line[start:i], nil
+}
+
+// parseLogfmtValue parses the value at the beginning of s, returning it along
+// with the number of bytes consumed.
+func parseLogfmtValue(s string) (any, int, error) {
+	if s == "" || isLogfmtSpace(s[0]) {
+		return nil, 0, nil
+	}
+
+	if s[0] == '"' {
+		return parseLogfmtQuoted(s)
+	}
+
+	end := strings.IndexAny(s, " \t")
+	if end < 0 {
+		end = len(s)
+	}
+
+	raw := s[:end]
+	if strings.ContainsAny(raw, `"=`) {
+		return nil, 0, errors.New("unexpected character in unquoted value") //nolint:err113
+	}
+
+	if raw == "null" {
+		return nil, end, nil
+	}
+
+	return raw, end, nil
+}
+
+// parseLogfmtQuoted parses the quoted value at the beginning of s, returning it
+// along with the number of bytes consumed.
+func parseLogfmtQuoted(s string) (any, int, error) {
+	end := closingQuote(s)
+	if end < 0 {
+		return nil, 0, errors.New("unterminated quoted value") //nolint:err113
+	}
+
+	if end+1 < len(s) && !isLogfmtSpace(s[end+1]) {
+		return nil, 0, errors.New("unexpected character after quoted value") //nolint:err113
+	}
+
+	val, err := strconv.Unquote(s[:end+1])
+	if err != nil {
+		return nil, 0, err //nolint:wrapcheck
+	}
+
+	return val, end + 1, nil
+}
+
+// closingQuote returns the index of the quote closing the one s starts with,
+// or -1 if there is none.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isLogfmtSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
@@ -0,0 +1,147 @@
+package fields_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func TestList_EncodeLogfmt(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		in       fields.List
+		expected string
+	}{
+		{
+			name:     "empty",
+			in:       fields.List{},
+			expected: "",
+		},
+		{
+			name:     "plain values",
+			in:       fields.List{fields.F("msg", "hello"), fields.Int("id", 1), fields.Bool("ok", true)},
+			expected: "msg=hello id=1 ok=true",
+		},
+		{
+			name: "quoted values",
+			in: fields.List{
+				fields.F("msg", "hello, world (again)"),
+				fields.F("empty", ""),
+				fields.F("eq", "a=b"),
+				fields.F("quote", `say "hi"`),
+				fields.F("nl", "a\nb"),
+				fields.F("str_null", "null"),
+			},
+			expected: `msg="hello, world (again)" empty="" eq="a=b" quote="say \"hi\"" nl="a\nb" str_null="null"`,
+		},
+		{
+			name:     "nil value",
+			in:       fields.List{fields.F("v", nil)},
+			expected: "v=null",
+		},
+		{
+			name:     "invalid key characters",
+			in:       fields.List{fields.F("a b=\"c\"", 1), fields.F("", 2)},
+			expected: "a_b__c_=1 _=2",
+		},
+		{
+			name:     "error value",
+			in:       fields.List{fields.Err("err", errors.New("boom failed"))}, //nolint:err113
+			expected: `err="boom failed"`,
+		},
+		{
+			name: "group and namespace",
+			in: fields.List{
+				fields.Group("req", fields.F("method", "GET")),
+				fields.Namespace("db"),
+				fields.F("query", "SELECT 1"),
+			},
+			expected: `req.method=GET db.query="SELECT 1"`,
+		},
+		{
+			name:     "sensitive value",
+			in:       fields.List{fields.Sensitive("token", "secret")},
+			expected: "token=" + fields.RedactedValue,
+		},
+	}
+
+	for _, tc := range tt {
+		b := &strings.Builder{}
+
+		require.NoError(t, tc.in.EncodeLogfmt(b), tc.name)
+		assert.Equal(t, tc.expected, b.String(), tc.name)
+	}
+}
+
+func TestDict_EncodeLogfmt(t *testing.T) {
+	t.Parallel()
+
+	b := &strings.Builder{}
+
+	require.NoError(t, fields.Dict{"msg": "hello world"}.EncodeLogfmt(b))
+	assert.Equal(t, `msg="hello world"`, b.String())
+}
+
+func TestParseLogfmt(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		in       string
+		expected fields.List
+	}{
+		{
+			name:     "empty",
+			in:       "  ",
+			expected: nil,
+		},
+		{
+			name:     "plain and quoted values",
+			in:       `msg="hello, world" id=1  quote="say \"hi\""`,
+			expected: fields.List{fields.F("msg", "hello, world"), fields.F("id", "1"), fields.F("quote", `say "hi"`)},
+		},
+		{
+			name:     "null and missing values",
+			in:       `a=null b= c d="null"`,
+			expected: fields.List{fields.F("a", nil), fields.F("b", nil), fields.F("c", nil), fields.F("d", "null")},
+		},
+	}
+
+	for _, tc := range tt {
+		list, err := fields.ParseLogfmt(tc.in)
+
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, list, tc.name)
+	}
+
+	for _, in := range []string{`=v`, `k="unterminated`, `k="v"x`, `k=a"b`, `k"=v`, `k=a=b`} {
+		_, err := fields.ParseLogfmt(in)
+
+		assert.ErrorIs(t, err, fields.ErrInvalidLogfmt, in)
+	}
+}
+
+func TestLogfmt_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := fields.List{
+		fields.F("msg", "multi\nline, (value)"),
+		fields.F("empty", ""),
+		fields.F("unicode", "héllo wörld"),
+		fields.F("nil", nil),
+	}
+
+	b := &strings.Builder{}
+	require.NoError(t, in.EncodeLogfmt(b))
+
+	out, err := fields.ParseLogfmt(b.String())
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+}
@@ -0,0 +1,126 @@
+package fields
+
+import (
+	"io"
+	"iter"
+	"strings"
+)
+
+// OrderedDict is a collection of unique fields, which, unlike [Dict], keeps
+// fields in the order their keys were first added. Updating the value of an
+// existing key keeps its position.
+//
+// Fields are stored as is, so fields created with typed constructors are not
+// boxed. The zero value is an empty OrderedDict ready to use.
+type OrderedDict struct {
+	list  List
+	index map[string]int
+}
+
+// NewOrderedDict creates a new OrderedDict with given fields added.
+//
+// Example:
+//
+//	d := fields.NewOrderedDict(fields.F("foo", 1), fields.F("bar", 2), fields.F("foo", 3))
+//	fmt.Println(d) // "(foo=3, bar=2)"
+func NewOrderedDict(fields ...Field) *OrderedDict {
+	d := &OrderedDict{
+		list:  make(List, 0, len(fields)),
+		index: make(map[string]int, len(fields)),
+	}
+
+	d.Add(fields...)
+
+	return d
+}
+
+// Add inserts or updates fields in the OrderedDict. New keys are appended to the
+// end, while existing ones are updated in place.
+func (d *OrderedDict) Add(fields ...Field) {
+	if d.index == nil {
+		d.index = make(map[string]int, len(fields))
+	}
+
+	for _, f := range fields {
+		if i, ok := d.index[f.K]; ok {
+			d.list[i] = f
+
+			continue
+		}
+
+		d.index[f.K] = len(d.list)
+		d.list = append(d.list, f)
+	}
+}
+
+// Get returns the field with given key, along with whether it was found.
+func (d *OrderedDict) Get(key string) (Field, bool) {
+	i, ok := d.index[key]
+	if !ok {
+		return Field{}, false //nolint:exhaustruct
+	}
+
+	return d.list[i], true
+}
+
+// Delete removes the field with given key, keeping the order of the rest.
+func (d *OrderedDict) Delete(key string) {
+	i, ok := d.index[key]
+	if !ok {
+		return
+	}
+
+	delete(d.index, key)
+	d.list = append(d.list[:i], d.list[i+1:]...)
+
+	for j := i; j < len(d.list); j++ {
+		d.index[d.list[j].K] = j
+	}
+}
+
+// Len returns the number of fields in the OrderedDict.
+func (d *OrderedDict) Len() int {
+	return len(d.list)
+}
+
+// ToList returns the fields of the OrderedDict as a List, in insertion order.
+// Returned List is a copy and can be modified freely.
+func (d *OrderedDict) ToList() List {
+	return append(List(nil), d.list...)
+}
+
+// ToDict converts the OrderedDict to a Dict.
+func (d *OrderedDict) ToDict() Dict {
+	return d.list.ToDict()
+}
+
+// All returns an iterator over all key-value pairs in the OrderedDict in
+// insertion order.
+func (d *OrderedDict) All() iter.Seq2[string, any] {
+	return d.list.All()
+}
+
+// WriteTo writes the OrderedDict as a string in the format "(key1=val1, key2=val2)"
+// to the provided builder, in insertion order. If the OrderedDict is empty,
+// nothing is written.
+func (d *OrderedDict) WriteTo(b *strings.Builder) {
+	d.list.WriteTo(b)
+}
+
+// String returns the OrderedDict as a string in the format "(key1=val1, key2=val2)".
+// Returns an empty string if the OrderedDict is empty.
+func (d *OrderedDict) String() string {
+	return d.list.String()
+}
+
+// EncodeLogfmt writes the OrderedDict to w as a single logfmt line, same as
+// [List.EncodeLogfmt] does.
+func (d *OrderedDict) EncodeLogfmt(w io.Writer) error {
+	return d.list.EncodeLogfmt(w)
+}
+
+// EncodeJSON writes the OrderedDict to w as a JSON object, same as
+// [List.EncodeJSON] does.
+func (d *OrderedDict) EncodeJSON(w io.Writer) error {
+	return d.list.EncodeJSON(w)
+}
@@ -0,0 +1,106 @@
+package fields_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func TestOrderedDict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Add keeps insertion order", func(t *testing.T) {
+		t.Parallel()
+
+		d := fields.NewOrderedDict(fields.F("foo", 1), fields.Int("bar", 2))
+		d.Add(fields.F("baz", 3), fields.F("foo", 4))
+
+		assert.Equal(t, 3, d.Len())
+		assert.Equal(t, fields.List{fields.F("foo", 4), fields.Int("bar", 2), fields.F("baz", 3)}, d.ToList())
+		assert.Equal(t, "(foo=4, bar=2, baz=3)", d.String())
+		assert.Equal(t, fields.Dict{"foo": 4, "bar": 2, "baz": 3}, d.ToDict())
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		t.Parallel()
+
+		var d fields.OrderedDict
+
+		assert.Empty(t, d.String())
+
+		_, ok := d.Get("foo")
+		assert.False(t, ok)
+
+		d.Delete("foo")
+		d.Add(fields.F("foo", "bar"))
+
+		assert.Equal(t, "(foo=bar)", d.String())
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Parallel()
+
+		d := fields.NewOrderedDict(fields.Int("foo", 1))
+
+		f, ok := d.Get("foo")
+		require.True(t, ok)
+		assert.Equal(t, fields.Int("foo", 1), f)
+
+		_, ok = d.Get("bar")
+		assert.False(t, ok)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		t.Parallel()
+
+		d := fields.NewOrderedDict(fields.F("a", 1), fields.F("b", 2), fields.F("c", 3))
+		d.Delete("a")
+		d.Delete("missing")
+		d.Add(fields.F("c", 4), fields.F("a", 5))
+
+		assert.Equal(t, fields.List{fields.F("b", 2), fields.F("c", 4), fields.F("a", 5)}, d.ToList())
+	})
+
+	t.Run("ToList returns copy", func(t *testing.T) {
+		t.Parallel()
+
+		d := fields.NewOrderedDict(fields.F("a", 1))
+		l := d.ToList()
+		l[0] = fields.F("b", 2)
+
+		assert.Equal(t, "(a=1)", d.String())
+	})
+
+	t.Run("All early exit", func(t *testing.T) {
+		t.Parallel()
+
+		d := fields.NewOrderedDict(fields.F("a", 1), fields.F("b", 2))
+
+		var seen []string
+		for k := range d.All() {
+			seen = append(seen, k)
+			break // stop after first
+		}
+
+		assert.Equal(t, []string{"a"}, seen)
+	})
+
+	t.Run("encoders", func(t *testing.T) {
+		t.Parallel()
+
+		d := fields.NewOrderedDict(fields.F("z", "hello world"), fields.F("a", 1))
+
+		sb := &strings.Builder{}
+		require.NoError(t, d.EncodeLogfmt(sb))
+		assert.Equal(t, `z="hello world" a=1`, sb.String())
+
+		jb := &bytes.Buffer{}
+		require.NoError(t, d.EncodeJSON(jb))
+		assert.Equal(t, `{"z":"hello world","a":1}`, jb.String())
+	})
+}
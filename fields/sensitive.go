@@ -0,0 +1,71 @@
+package fields
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// RedactedValue is the text sensitive values are rendered as.
+const RedactedValue = "[REDACTED]"
+
+// SensitiveValue wraps a value, such as token, password or e-mail, which must not
+// be rendered verbatim. It is rendered as [RedactedValue] by [Field.String],
+// [List.String], [Dict.String], fmt package with any verb, JSON marshalling and
+// [slog], therefore by logger adapters as well. The raw value is only available
+// explicitly, via [SensitiveValue.Value] or [Raw].
+type SensitiveValue struct {
+	v any
+}
+
+// Sensitive creates a new Field with the given key and value marked as
+// sensitive.
+//
+// Example:
+//
+//	f := fields.Sensitive("password", "qwerty")
+//	f.String() // "password=[REDACTED]"
+func Sensitive(key string, value any) Field {
+	return Field{K: key, V: SensitiveValue{v: value}, typ: TypeAny, num: 0, str: ""}
+}
+
+// Value returns the raw value.
+func (s SensitiveValue) Value() any {
+	return s.v
+}
+
+// String implements [fmt.Stringer], returning [RedactedValue].
+func (s SensitiveValue) String() string {
+	return RedactedValue
+}
+
+// Format implements [fmt.Formatter], rendering [RedactedValue] for any verb.
+func (s SensitiveValue) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, RedactedValue)
+}
+
+// MarshalJSON implements [json.Marshaler], representing the value as
+// [RedactedValue] string.
+func (s SensitiveValue) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + RedactedValue + `"`), nil
+}
+
+// MarshalText implements [encoding.TextMarshaler], returning [RedactedValue].
+func (s SensitiveValue) MarshalText() ([]byte, error) {
+	return []byte(RedactedValue), nil
+}
+
+// LogValue implements [slog.LogValuer], returning [RedactedValue].
+func (s SensitiveValue) LogValue() slog.Value {
+	return slog.StringValue(RedactedValue)
+}
+
+// Raw returns the raw value of v in case it is marked as sensitive, or v itself
+// otherwise.
+func Raw(v any) any {
+	if s, ok := v.(SensitiveValue); ok {
+		return s.v
+	}
+
+	return v
+}
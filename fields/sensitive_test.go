@@ -0,0 +1,53 @@
+package fields_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func TestSensitive(t *testing.T) {
+	t.Parallel()
+
+	f := fields.Sensitive("password", "qwerty")
+
+	assert.Equal(t, "password", f.K)
+	assert.Equal(t, "password=[REDACTED]", f.String())
+	assert.Equal(t, "(user=bob, password=[REDACTED])", fields.List{fields.F("user", "bob"), f}.String())
+	assert.Equal(t, "(password=[REDACTED])", fields.List{f}.ToDict().String())
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%d", "%x"} {
+		assert.Equal(t, fields.RedactedValue, fmt.Sprintf(verb, f.V), verb)
+	}
+
+	data, err := json.Marshal(map[string]any{"password": f.V})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"password":"[REDACTED]"}`, string(data))
+
+	b := &bytes.Buffer{}
+	slog.New(slog.NewJSONHandler(b, nil)).Info("msg", f.K, f.V)
+	assert.Contains(t, b.String(), `"password":"[REDACTED]"`)
+
+	b.Reset()
+	slog.New(slog.NewTextHandler(b, nil)).Info("msg", f.K, f.V)
+	assert.Contains(t, b.String(), `password=[REDACTED]`)
+}
+
+func TestRaw(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "qwerty", fields.Raw(fields.Sensitive("password", "qwerty").V))
+	assert.Equal(t, 42, fields.Raw(42))
+	assert.Nil(t, fields.Raw(nil))
+
+	v, ok := fields.Sensitive("token", []byte("abc")).V.(fields.SensitiveValue)
+	require.True(t, ok)
+	assert.Equal(t, []byte("abc"), v.Value())
+}
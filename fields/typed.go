@@ -0,0 +1,296 @@
+package fields
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type is the type of value representation of a Field.
+type Type uint8
+
+// Types of field values. Fields created with [F] are of [TypeAny], fields
+// created with [Lazy], [Group] and [Namespace] are of [TypeLazy], [TypeGroup]
+// and [TypeNamespace], while typed constructors produce the corresponding
+// compact types.
+const (
+	TypeAny Type = iota
+	TypeString
+	TypeInt
+	TypeInt64
+	TypeUint
+	TypeUint64
+	TypeFloat64
+	TypeBool
+	TypeDuration
+	TypeTime
+	TypeError
+	TypeLazy
+	TypeGroup
+	TypeNamespace
+)
+
+// String creates a new Field with the given key and string value.
+func String(key string, value string) Field {
+	return Field{K: key, V: nil, typ: TypeString, num: 0, str: value}
+}
+
+// Int creates a new Field with the given key and int value.
+func Int(key string, value int) Field {
+	return Field{K: key, V: nil, typ: TypeInt, num: uint64(value), str: ""} //nolint:gosec
+}
+
+// Int64 creates a new Field with the given key and int64 value.
+func Int64(key string, value int64) Field {
+	return Field{K: key, V: nil, typ: TypeInt64, num: uint64(value), str: ""} //nolint:gosec
+}
+
+// Uint creates a new Field with the given key and uint value.
+func Uint(key string, value uint) Field {
+	return Field{K: key, V: nil, typ: TypeUint, num: uint64(value), str: ""}
+}
+
+// Uint64 creates a new Field with the given key and uint64 value.
+func Uint64(key string, value uint64) Field {
+	return Field{K: key, V: nil, typ: TypeUint64, num: value, str: ""}
+}
+
+// Float64 creates a new Field with the given key and float64 value.
+func Float64(key string, value float64) Field {
+	return Field{K: key, V: nil, typ: TypeFloat64, num: math.Float64bits(value), str: ""}
+}
+
+// Bool creates a new Field with the given key and bool value.
+func Bool(key string, value bool) Field {
+	var num uint64
+	if value {
+		num = 1
+	}
+
+	return Field{K: key, V: nil, typ: TypeBool, num: num, str: ""}
+}
+
+// Duration creates a new Field with the given key and duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{K: key, V: nil, typ: TypeDuration, num: uint64(value), str: ""} //nolint:gosec
+}
+
+// Bounds of the time representable as nanoseconds since Unix epoch.
+//
+//nolint:gochecknoglobals
+var (
+	minNanoTime = time.Unix(0, math.MinInt64)
+	maxNanoTime = time.Unix(0, math.MaxInt64)
+	// zeroTime is pre-boxed zero time, which is stored without allocation.
+	zeroTime any = time.Time{}
+)
+
+// Time creates a new Field with the given key and time value. The monotonic
+// clock reading is not preserved.
+//
+// Times outside of the years 1678 to 2262, which cannot be represented as
+// nanoseconds since Unix epoch, are stored boxed.
+func Time(key string, value time.Time) Field {
+	if value.Before(minNanoTime) || value.After(maxNanoTime) {
+		boxed := zeroTime
+		if value != (time.Time{}) {
+			boxed = value.Round(0)
+		}
+
+		return Field{K: key, V: boxed, typ: TypeTime, num: 0, str: ""}
+	}
+
+	// location pointer is stored as is, which does not require allocation.
+	return Field{K: key, V: value.Location(), typ: TypeTime, num: uint64(value.UnixNano()), str: ""} //nolint:gosec
+}
+
+// Err creates a new Field with the given key and error value.
+func Err(key string, err error) Field {
+	return Field{K: key, V: err, typ: TypeError, num: 0, str: ""}
+}
+
+// Type returns the type of value representation of the Field.
+func (f Field) Type() Type {
+	return f.typ
+}
+
+// Value returns the value of the Field, regardless of the way it was created.
+// Values of typed fields are returned as their original types, which requires
+// boxing them into interface. Values of lazy fields are computed.
+func (f Field) Value() any {
+	switch f.typ {
+	case TypeLazy:
+		return f.lazyValue()
+	case TypeAny, TypeError, TypeGroup, TypeNamespace:
+		return f.V
+	default:
+		return f.scalarValue()
+	}
+}
+
+// scalarValue returns the value of the Field of scalar type, which is stored
+// without boxing.
+func (f Field) scalarValue() any {
+	switch f.typ { //nolint:exhaustive
+	case TypeString:
+		return f.str
+	case TypeInt:
+		return int(f.Int64Value())
+	case TypeInt64:
+		return f.Int64Value()
+	case TypeUint:
+		return uint(f.num)
+	case TypeUint64:
+		return f.num
+	case TypeFloat64:
+		return f.Float64Value()
+	case TypeBool:
+		return f.BoolValue()
+	case TypeDuration:
+		return f.DurationValue()
+	case TypeTime:
+		return f.TimeValue()
+	default:
+		return f.V
+	}
+}
+
+// StringValue returns the value of the Field of [TypeString].
+func (f Field) StringValue() string {
+	return f.str
+}
+
+// Int64Value returns the value of the Field of [TypeInt] or [TypeInt64].
+func (f Field) Int64Value() int64 {
+	return int64(f.num) //nolint:gosec
+}
+
+// Uint64Value returns the value of the Field of [TypeUint] or [TypeUint64].
+func (f Field) Uint64Value() uint64 {
+	return f.num
+}
+
+// Float64Value returns the value of the Field of [TypeFloat64].
+func (f Field) Float64Value() float64 {
+	return math.Float64frombits(f.num)
+}
+
+// BoolValue returns the value of the Field of [TypeBool].
+func (f Field) BoolValue() bool {
+	return f.num == 1
+}
+
+// DurationValue returns the value of the Field of [TypeDuration].
+func (f Field) DurationValue() time.Duration {
+	return time.Duration(f.num) //nolint:gosec
+}
+
+// TimeValue returns the value of the Field of [TypeTime].
+func (f Field) TimeValue() time.Time {
+	if t, ok := f.V.(time.Time); ok {
+		return t
+	}
+
+	t := time.Unix(0, int64(f.num)) //nolint:gosec
+
+	if loc, ok := f.V.(*time.Location); ok {
+		return t.In(loc)
+	}
+
+	return t
+}
+
+// ErrorValue returns the value of the Field of [TypeError].
+func (f Field) ErrorValue() error {
+	err, _ := f.V.(error)
+
+	return err
+}
+
+// Attr returns the Field as [slog.Attr], using native slog values for typed
+// fields. Value of lazy field is computed when slog handler resolves it. Group
+// is represented as slog group, while namespace is represented as an empty group,
+// use [List.Attrs] to nest the fields following it.
+func (f Field) Attr() slog.Attr {
+	switch f.typ {
+	case TypeLazy:
+		return slog.Any(f.K, lazyValuer(f.lazyValue))
+	case TypeGroup:
+		return slog.Attr{Key: f.K, Value: slog.GroupValue(f.GroupValue().Attrs()...)}
+	case TypeNamespace:
+		return slog.Attr{Key: f.K, Value: slog.GroupValue()}
+	case TypeAny, TypeError:
+		return slog.Any(f.K, f.V)
+	default:
+		return f.scalarAttr()
+	}
+}
+
+// scalarAttr returns the Field of scalar type as [slog.Attr].
+func (f Field) scalarAttr() slog.Attr {
+	switch f.typ { //nolint:exhaustive
+	case TypeString:
+		return slog.String(f.K, f.str)
+	case TypeInt, TypeInt64:
+		return slog.Int64(f.K, f.Int64Value())
+	case TypeUint, TypeUint64:
+		return slog.Uint64(f.K, f.num)
+	case TypeFloat64:
+		return slog.Float64(f.K, f.Float64Value())
+	case TypeBool:
+		return slog.Bool(f.K, f.BoolValue())
+	case TypeDuration:
+		return slog.Duration(f.K, f.DurationValue())
+	case TypeTime:
+		return slog.Time(f.K, f.TimeValue())
+	default:
+		return slog.Any(f.K, f.V)
+	}
+}
+
+// writeValueTo writes the value of typed Field the same way [F] fields are
+// written, but without boxing it.
+func (f Field) writeValueTo(b *strings.Builder) {
+	switch f.typ {
+	case TypeError:
+		if err := f.ErrorValue(); err != nil {
+			b.WriteString(err.Error())
+		} else {
+			b.WriteString("<nil>")
+		}
+	case TypeLazy:
+		writeKVValueTo(b, f.lazyValue())
+	case TypeGroup, TypeNamespace:
+		b.WriteString("(")
+		writeListTo(b, f.GroupValue())
+		b.WriteString(")")
+	case TypeAny:
+		writeKVValueTo(b, f.V)
+	default:
+		f.writeScalarTo(b)
+	}
+}
+
+// writeScalarTo writes the value of the Field of scalar type without boxing it.
+func (f Field) writeScalarTo(b *strings.Builder) {
+	var buf [32]byte
+
+	switch f.typ { //nolint:exhaustive
+	case TypeString:
+		b.WriteString(f.str)
+	case TypeInt, TypeInt64:
+		b.Write(strconv.AppendInt(buf[:0], f.Int64Value(), 10)) //nolint:mnd
+	case TypeUint, TypeUint64:
+		b.Write(strconv.AppendUint(buf[:0], f.num, 10)) //nolint:mnd
+	case TypeFloat64:
+		b.Write(strconv.AppendFloat(buf[:0], f.Float64Value(), 'g', -1, 64)) //nolint:mnd
+	case TypeBool:
+		b.Write(strconv.AppendBool(buf[:0], f.BoolValue()))
+	case TypeDuration:
+		b.WriteString(f.DurationValue().String())
+	case TypeTime:
+		b.WriteString(f.TimeValue().String())
+	}
+}
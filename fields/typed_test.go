@@ -0,0 +1,200 @@
+package fields_test
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+func TestTypedFields(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error") //nolint:err113
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 10, loc)
+	farFuture := time.Date(3000, 1, 2, 3, 4, 5, 6, loc)
+
+	tests := []struct {
+		name  string
+		field fields.Field
+		typ   fields.Type
+		value any
+		str   string
+		attr  slog.Value
+	}{
+		{
+			name:  "string",
+			field: fields.String("k", "value"),
+			typ:   fields.TypeString,
+			value: "value",
+			str:   "k=value",
+			attr:  slog.StringValue("value"),
+		},
+		{
+			name:  "int",
+			field: fields.Int("k", -42),
+			typ:   fields.TypeInt,
+			value: -42,
+			str:   "k=-42",
+			attr:  slog.Int64Value(-42),
+		},
+		{
+			name:  "int64",
+			field: fields.Int64("k", math.MinInt64),
+			typ:   fields.TypeInt64,
+			value: int64(math.MinInt64),
+			str:   "k=-9223372036854775808",
+			attr:  slog.Int64Value(math.MinInt64),
+		},
+		{
+			name:  "uint",
+			field: fields.Uint("k", 42),
+			typ:   fields.TypeUint,
+			value: uint(42),
+			str:   "k=42",
+			attr:  slog.Uint64Value(42),
+		},
+		{
+			name:  "uint64",
+			field: fields.Uint64("k", math.MaxUint64),
+			typ:   fields.TypeUint64,
+			value: uint64(math.MaxUint64),
+			str:   "k=18446744073709551615",
+			attr:  slog.Uint64Value(math.MaxUint64),
+		},
+		{
+			name:  "float64",
+			field: fields.Float64("k", 3.25),
+			typ:   fields.TypeFloat64,
+			value: 3.25,
+			str:   "k=3.25",
+			attr:  slog.Float64Value(3.25),
+		},
+		{
+			name:  "bool",
+			field: fields.Bool("k", true),
+			typ:   fields.TypeBool,
+			value: true,
+			str:   "k=true",
+			attr:  slog.BoolValue(true),
+		},
+		{
+			name:  "duration",
+			field: fields.Duration("k", 1500*time.Millisecond),
+			typ:   fields.TypeDuration,
+			value: 1500 * time.Millisecond,
+			str:   "k=1.5s",
+			attr:  slog.DurationValue(1500 * time.Millisecond),
+		},
+		{
+			name:  "time",
+			field: fields.Time("k", ts),
+			typ:   fields.TypeTime,
+			value: ts,
+			str:   "k=" + ts.String(),
+			attr:  slog.TimeValue(ts),
+		},
+		{
+			name:  "zero time",
+			field: fields.Time("k", time.Time{}),
+			typ:   fields.TypeTime,
+			value: time.Time{},
+			str:   "k=0001-01-01 00:00:00 +0000 UTC",
+			attr:  slog.TimeValue(time.Time{}),
+		},
+		{
+			name:  "far future time",
+			field: fields.Time("k", farFuture),
+			typ:   fields.TypeTime,
+			value: farFuture,
+			str:   "k=3000-01-02 03:04:05.000000006 +0300 UTC+3",
+			attr:  slog.TimeValue(farFuture),
+		},
+		{
+			name:  "error",
+			field: fields.Err("k", errTest),
+			typ:   fields.TypeError,
+			value: errTest,
+			str:   "k=test error",
+			attr:  slog.AnyValue(errTest),
+		},
+		{
+			name:  "nil error",
+			field: fields.Err("k", nil),
+			typ:   fields.TypeError,
+			value: nil,
+			str:   "k=<nil>",
+			attr:  slog.AnyValue(nil),
+		},
+		{
+			name:  "any",
+			field: fields.F("k", []int{1, 2}),
+			typ:   fields.TypeAny,
+			value: []int{1, 2},
+			str:   "k=[1 2]",
+			attr:  slog.AnyValue([]int{1, 2}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, "k", tt.field.K)
+			assert.Equal(t, tt.typ, tt.field.Type())
+			assert.Equal(t, tt.value, tt.field.Value())
+			assert.Equal(t, tt.str, tt.field.String())
+			assert.Equal(t, tt.str[2:], tt.field.ValueString())
+			assert.Equal(t, fields.F("k", tt.value).String(), tt.field.String())
+
+			attr := tt.field.Attr()
+			assert.Equal(t, "k", attr.Key)
+			assert.Equal(t, tt.attr.Kind(), attr.Value.Kind())
+			assert.Equal(t, tt.attr.Any(), attr.Value.Any())
+		})
+	}
+}
+
+func TestTypedFields_Collections(t *testing.T) {
+	t.Parallel()
+
+	l := fields.List{fields.Int("a", 1), fields.String("b", "x"), fields.F("c", true)}
+
+	assert.Equal(t, "(a=1, b=x, c=true)", l.String())
+	assert.Equal(t, fields.Dict{"a": 1, "b": "x", "c": true}, l.ToDict())
+
+	d := fields.Dict{}
+	d.Add(fields.Duration("d", time.Second))
+	assert.Equal(t, fields.Dict{"d": time.Second}, d)
+
+	for k, v := range l.All() {
+		assert.Equal(t, l.ToDict()[k], v)
+	}
+}
+
+//nolint:paralleltest // allocations are measured
+func TestTypedFields_Allocations(t *testing.T) {
+	ts := time.Now()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		l := fields.List{
+			fields.String("string", "value"),
+			fields.Int("int", 1024),
+			fields.Float64("float", 1.5),
+			fields.Bool("bool", true),
+			fields.Duration("duration", time.Second),
+			fields.Time("time", ts),
+			fields.Time("zero time", time.Time{}),
+		}
+
+		_ = l[0].Type()
+	})
+
+	assert.Zero(t, allocs) //nolint:testifylint
+}
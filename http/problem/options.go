@@ -0,0 +1,89 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"dev.gaijin.team/go/golib/e"
+)
+
+// Option is a functional option for configuring [Registry].
+type Option func(*Registry)
+
+// WithStatus maps errors matching target with [errors.Is] to the given status.
+// Target is usually a sentinel *e.Err, errors derived from it with
+// [e.Err.WithFields] or [e.Err.Wrap] match it as well.
+//
+// Mappings are checked in the order they were added, before the kind mappings.
+func WithStatus(target error, status int) Option {
+	return WithPredicate(func(err error) bool {
+		return errors.Is(err, target)
+	}, status)
+}
+
+// WithPredicate maps errors the predicate reports true for to the given status.
+//
+// Mappings are checked in the order they were added, before the kind mappings.
+func WithPredicate(match func(err error) bool, status int) Option {
+	return func(r *Registry) {
+		r.rules = append(r.rules, rule{match: match, status: status})
+	}
+}
+
+// WithKindStatus maps errors of given kind, as reported by [e.KindOf], to the
+// given status, overriding the default mapping of the kind.
+func WithKindStatus(k e.Kind, status int) Option {
+	return func(r *Registry) {
+		r.kinds[k] = status
+	}
+}
+
+// WithDefaultStatus sets the status of errors not matched by any mapping,
+// [http.StatusInternalServerError] is used by default.
+func WithDefaultStatus(status int) Option {
+	return func(r *Registry) {
+		r.defaultStatus = status
+	}
+}
+
+// WithPublicFields marks fields with given keys as public, which makes them
+// exposed as extension members of problem details. Fields are collected from
+// the whole error chain, the outermost value of the key wins.
+func WithPublicFields(keys ...string) Option {
+	return func(r *Registry) {
+		for _, k := range keys {
+			r.public[k] = struct{}{}
+		}
+	}
+}
+
+// WithType sets the function resolving problem type URI of the error. By
+// default the type is omitted, which stands for "about:blank".
+func WithType(fn func(err error, status int) string) Option {
+	return func(r *Registry) {
+		r.typeOf = fn
+	}
+}
+
+// WithDetail sets the function resolving the human-readable explanation of the
+// error. By default the detail is omitted, since error messages usually carry
+// internal information.
+func WithDetail(fn func(err error, status int) string) Option {
+	return func(r *Registry) {
+		r.detailOf = fn
+	}
+}
+
+// defaultKinds returns the default mapping of error kinds to statuses.
+func defaultKinds() map[e.Kind]int {
+	return map[e.Kind]int{
+		e.KindInvalidArgument:  http.StatusBadRequest,
+		e.KindUnauthenticated:  http.StatusUnauthorized,
+		e.KindPermissionDenied: http.StatusForbidden,
+		e.KindNotFound:         http.StatusNotFound,
+		e.KindConflict:         http.StatusConflict,
+		e.KindInternal:         http.StatusInternalServerError,
+		e.KindUnavailable:      http.StatusServiceUnavailable,
+		e.KindDeadlineExceeded: http.StatusGatewayTimeout,
+	}
+}
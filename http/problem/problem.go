@@ -0,0 +1,99 @@
+// Package problem maps errors to HTTP responses with problem details body, as
+// defined by RFC 9457.
+//
+// The status code of an error is resolved by a [Registry], which matches the
+// error chain against registered sentinel errors and predicates, falling back
+// to the [e.Kind] of the chain:
+//
+//	var ErrUserNotFound = e.New("user not found")
+//
+//	reg := problem.NewRegistry(
+//		problem.WithStatus(ErrUserNotFound, http.StatusNotFound),
+//		problem.WithPublicFields("user_id"),
+//	)
+//
+//	reg.Write(w, r, ErrUserNotFound.WithField("user_id", 42))
+//	// {"title":"Not Found","status":404,"instance":"/users/42","user_id":42}
+//
+// Fields of the error chain are never exposed, unless their keys are explicitly
+// marked as public with [WithPublicFields].
+package problem
+
+import (
+	"bytes"
+
+	"dev.gaijin.team/go/golib/fields"
+)
+
+// ContentType is the media type of problem details JSON body.
+const ContentType = "application/problem+json"
+
+// Problem is the problem details object, see RFC 9457.
+type Problem struct {
+	// Type is the URI reference identifying the problem type. Empty value is
+	// equivalent to "about:blank".
+	Type string
+	// Title is the short human-readable summary of the problem type.
+	Title string
+	// Status is the HTTP status code.
+	Status int
+	// Detail is the human-readable explanation of this problem occurrence.
+	Detail string
+	// Instance is the URI reference identifying this problem occurrence.
+	Instance string
+	// Extensions are the additional members of the problem details object.
+	// Extensions named the same as standard members are ignored.
+	Extensions fields.List
+}
+
+// Names of standard problem details members.
+const (
+	memberType     = "type"
+	memberTitle    = "title"
+	memberStatus   = "status"
+	memberDetail   = "detail"
+	memberInstance = "instance"
+)
+
+// MarshalJSON implements [json.Marshaler]. Empty standard members are omitted,
+// and extensions are placed at the top level of the object, keeping their order.
+// Extensions are written the same as [fields.List.EncodeJSON] does.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(fields.List, 0, 5+len(p.Extensions)) //nolint:mnd
+
+	if p.Type != "" {
+		members = append(members, fields.String(memberType, p.Type))
+	}
+
+	if p.Title != "" {
+		members = append(members, fields.String(memberTitle, p.Title))
+	}
+
+	if p.Status != 0 {
+		members = append(members, fields.Int(memberStatus, p.Status))
+	}
+
+	if p.Detail != "" {
+		members = append(members, fields.String(memberDetail, p.Detail))
+	}
+
+	if p.Instance != "" {
+		members = append(members, fields.String(memberInstance, p.Instance))
+	}
+
+	for _, f := range p.Extensions {
+		switch f.K {
+		case memberType, memberTitle, memberStatus, memberDetail, memberInstance:
+			continue
+		}
+
+		members = append(members, f)
+	}
+
+	b := &bytes.Buffer{}
+	if err := members.EncodeJSON(b); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return b.Bytes(), nil
+}
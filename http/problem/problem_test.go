@@ -0,0 +1,77 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/http/problem"
+)
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		in       problem.Problem
+		expected string
+	}{
+		{
+			name:     "empty",
+			in:       problem.Problem{}, //nolint:exhaustruct
+			expected: `{}`,
+		},
+		{
+			name: "all members",
+			in: problem.Problem{
+				Type:       "https://example.com/probs/out-of-credit",
+				Title:      "Forbidden",
+				Status:     403,
+				Detail:     "Your current balance is 30",
+				Instance:   "/account/12345",
+				Extensions: fields.List{fields.F("balance", 30), fields.F("accounts", []string{"a", "b"})},
+			},
+			expected: `{"type":"https://example.com/probs/out-of-credit","title":"Forbidden","status":403,` +
+				`"detail":"Your current balance is 30","instance":"/account/12345","balance":30,"accounts":["a","b"]}`,
+		},
+		{
+			name: "standard members are not overridden",
+			in: problem.Problem{ //nolint:exhaustruct
+				Status:     404,
+				Extensions: fields.List{fields.F("status", 200), fields.F("title", "x"), fields.F("id", 1)},
+			},
+			expected: `{"status":404,"id":1}`,
+		},
+		{
+			name: "error value",
+			in: problem.Problem{ //nolint:exhaustruct
+				Extensions: fields.List{fields.F("err", errors.New("boom"))}, //nolint:err113
+			},
+			expected: `{"err":"boom"}`,
+		},
+		{
+			name: "unmarshallable value",
+			in: problem.Problem{ //nolint:exhaustruct
+				Extensions: fields.List{fields.F("ch", make(chan int))},
+			},
+			expected: `{"ch":"0x`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(tt.in)
+			require.NoError(t, err)
+
+			assert.True(t, strings.HasPrefix(string(data), tt.expected), string(data))
+			assert.True(t, json.Valid(data))
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package problem
+
+import (
+	"net/http"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+)
+
+// Registry resolves HTTP status codes and problem details of errors. It is
+// configured on creation and is safe for concurrent use.
+type Registry struct {
+	rules         []rule
+	kinds         map[e.Kind]int
+	defaultStatus int
+	public        map[string]struct{}
+	typeOf        func(err error, status int) string
+	detailOf      func(err error, status int) string
+}
+
+type rule struct {
+	match  func(err error) bool
+	status int
+}
+
+// NewRegistry returns a new Registry configured with given options. Error kinds
+// are mapped to the statuses by default, see [WithKindStatus]:
+//
+//   - [e.KindInvalidArgument] - 400 Bad Request;
+//   - [e.KindUnauthenticated] - 401 Unauthorized;
+//   - [e.KindPermissionDenied] - 403 Forbidden;
+//   - [e.KindNotFound] - 404 Not Found;
+//   - [e.KindConflict] - 409 Conflict;
+//   - [e.KindInternal] - 500 Internal Server Error;
+//   - [e.KindUnavailable] - 503 Service Unavailable;
+//   - [e.KindDeadlineExceeded] - 504 Gateway Timeout.
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{
+		rules:         nil,
+		kinds:         defaultKinds(),
+		defaultStatus: http.StatusInternalServerError,
+		public:        make(map[string]struct{}),
+		typeOf:        nil,
+		detailOf:      nil,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Status returns the HTTP status code of err. Mappings added by [WithStatus] and
+// [WithPredicate] are checked first, in the order they were added, then the
+// kind of error is checked. If nothing matches, the default status is returned.
+func (r *Registry) Status(err error) int {
+	for _, rl := range r.rules {
+		if rl.match(err) {
+			return rl.status
+		}
+	}
+
+	if status, ok := r.kinds[e.KindOf(err)]; ok {
+		return status
+	}
+
+	return r.defaultStatus
+}
+
+// Problem returns problem details of err, with public fields of the error chain
+// as extension members. Instance is left empty, see [Registry.Write].
+func (r *Registry) Problem(err error) Problem {
+	status := r.Status(err)
+
+	p := Problem{
+		Type:       "",
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     "",
+		Instance:   "",
+		Extensions: r.publicFields(err),
+	}
+
+	if r.typeOf != nil {
+		p.Type = r.typeOf(err, status)
+	}
+
+	if r.detailOf != nil {
+		p.Detail = r.detailOf(err, status)
+	}
+
+	return p
+}
+
+func (r *Registry) publicFields(err error) fields.List {
+	if len(r.public) == 0 {
+		return nil
+	}
+
+	var list fields.List
+
+	for _, f := range e.ChainFields(err, e.CollisionKeepOuter) {
+		if _, ok := r.public[f.K]; ok {
+			list = append(list, f)
+		}
+	}
+
+	return list
+}
+
+// Write responds with problem details of err, using the request path as problem
+// instance. Its signature matches error handlers of net/http middlewares, such
+// as sign.ErrorHandler.
+func (r *Registry) Write(w http.ResponseWriter, req *http.Request, err error) {
+	p := r.Problem(err)
+
+	if req != nil && req.URL != nil {
+		p.Instance = req.URL.Path
+	}
+
+	data, _ := p.MarshalJSON()
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,129 @@
+package problem_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"dev.gaijin.team/go/golib/e"
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/http/problem"
+)
+
+var (
+	errUserNotFound = e.New("user not found")
+	errQuota        = e.New("quota exceeded").WithKind(e.KindUnavailable)
+	errTimeout      = errors.New("timeout")
+)
+
+func TestRegistry_Status(t *testing.T) {
+	t.Parallel()
+
+	reg := problem.NewRegistry(
+		problem.WithStatus(errUserNotFound, http.StatusNotFound),
+		problem.WithPredicate(func(err error) bool {
+			return errors.Is(err, errTimeout)
+		}, http.StatusRequestTimeout),
+		problem.WithStatus(errQuota, http.StatusTooManyRequests),
+		problem.WithKindStatus(e.KindConflict, http.StatusPreconditionFailed),
+	)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "nil", err: nil, expected: http.StatusInternalServerError},
+		{name: "unknown", err: errors.New("err"), expected: http.StatusInternalServerError}, //nolint:err113
+		{name: "sentinel", err: errUserNotFound, expected: http.StatusNotFound},
+		{name: "derived sentinel", err: errUserNotFound.WithField("id", 1), expected: http.StatusNotFound},
+		{name: "wrapped sentinel", err: e.New("get user").Wrap(errUserNotFound), expected: http.StatusNotFound},
+		{name: "foreign wrapper", err: fmt.Errorf("ctx: %w", errUserNotFound), expected: http.StatusNotFound},
+		{name: "predicate", err: e.NewFrom("request", errTimeout), expected: http.StatusRequestTimeout},
+		{name: "rule before kind", err: errQuota, expected: http.StatusTooManyRequests},
+		{name: "default kind", err: e.New("err").WithKind(e.KindInvalidArgument), expected: http.StatusBadRequest},
+		{name: "overridden kind", err: e.New("err").WithKind(e.KindConflict), expected: http.StatusPreconditionFailed},
+		{
+			name:     "outer kind",
+			err:      e.New("err").WithKind(e.KindNotFound).WithField("id", 1).WithKind(e.KindPermissionDenied),
+			expected: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, reg.Status(tt.err))
+		})
+	}
+
+	fallback := problem.NewRegistry(problem.WithDefaultStatus(http.StatusBadGateway))
+
+	assert.Equal(t, http.StatusBadGateway, fallback.Status(nil))
+}
+
+func TestRegistry_Problem(t *testing.T) {
+	t.Parallel()
+
+	err := e.New("get user", fields.F("user_id", 42), fields.F("query", "SELECT 1")).
+		Wrap(errUserNotFound.WithFields(fields.F("user_id", 1), fields.F("region", "eu"), fields.F("token", "secret")))
+
+	reg := problem.NewRegistry(problem.WithStatus(errUserNotFound, http.StatusNotFound))
+	assert.Equal(t, problem.Problem{
+		Type:       "",
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Detail:     "",
+		Instance:   "",
+		Extensions: nil,
+	}, reg.Problem(err))
+
+	reg = problem.NewRegistry(
+		problem.WithStatus(errUserNotFound, http.StatusNotFound),
+		problem.WithPublicFields("user_id", "region"),
+		problem.WithType(func(_ error, status int) string {
+			return fmt.Sprintf("https://example.com/problems/%d", status)
+		}),
+		problem.WithDetail(func(err error, _ int) string {
+			if errors.Is(err, errUserNotFound) {
+				return errUserNotFound.Reason()
+			}
+
+			return ""
+		}),
+	)
+	assert.Equal(t, problem.Problem{
+		Type:       "https://example.com/problems/404",
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Detail:     "user not found",
+		Instance:   "",
+		Extensions: fields.List{fields.F("user_id", 42), fields.F("region", "eu")},
+	}, reg.Problem(err))
+}
+
+func TestRegistry_Write(t *testing.T) {
+	t.Parallel()
+
+	reg := problem.NewRegistry(problem.WithPublicFields("user_id"))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/42?full=1", nil)
+
+	reg.Write(w, r, errUserNotFound.WithKind(e.KindNotFound).WithField("user_id", 42))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"title":"Not Found","status":404,"instance":"/users/42","user_id":42}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	reg.Write(w, nil, errors.New("internal details")) //nolint:err113
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"title":"Internal Server Error","status":500}`, w.Body.String())
+}
@@ -0,0 +1,162 @@
+package sign
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"strconv"
+)
+
+// Algorithm identifies the algorithm used to produce a signature. It is encoded
+// into the signature alongside the version, so verifier can make sure that the
+// signature was produced by the expected kind of key.
+type Algorithm byte
+
+const (
+	// AlgRSA is RSA PKCS #1 v1.5 signature of SHA-256 digest.
+	AlgRSA Algorithm = iota
+	// AlgEd25519 is Ed25519 signature of SHA-256 digest.
+	AlgEd25519
+	// AlgECDSAP256 is ASN.1 encoded ECDSA signature of SHA-256 digest using P-256 curve.
+	AlgECDSAP256
+	// AlgHMACSHA256 is HMAC-SHA256 of SHA-256 digest.
+	AlgHMACSHA256
+)
+
+const (
+	versionMask    = 0x0f
+	algorithmShift = 4
+)
+
+var (
+	errVerification     = errors.New("verification failed")
+	errUnsupportedCurve = errors.New("unsupported curve, P-256 is expected")
+)
+
+func (a Algorithm) String() string {
+	switch a {
+	case AlgRSA:
+		return "rsa"
+	case AlgEd25519:
+		return "ed25519"
+	case AlgECDSAP256:
+		return "ecdsa-p256"
+	case AlgHMACSHA256:
+		return "hmac-sha256"
+
+	default:
+		return "unknown(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+func (a Algorithm) valid() bool {
+	return a <= AlgHMACSHA256
+}
+
+// scheme returns the first byte of the signature which encodes both version
+// and algorithm.
+func scheme(v Version, alg Algorithm) byte {
+	return byte(alg)<<algorithmShift | byte(v)&versionMask
+}
+
+// algorithmOf returns the algorithm of signer or verifier. Implementations that
+// do not report their algorithm are considered to be RSA ones, as it was the
+// only supported algorithm before.
+func algorithmOf(v any) Algorithm {
+	if a, ok := v.(interface{ Algorithm() Algorithm }); ok {
+		return a.Algorithm()
+	}
+
+	return AlgRSA
+}
+
+type Ed25519Signer ed25519.PrivateKey
+
+func (key Ed25519Signer) Sign(digest []byte) (Signature, error) {
+	return ed25519.Sign(ed25519.PrivateKey(key), digest), nil
+}
+
+func (Ed25519Signer) Algorithm() Algorithm {
+	return AlgEd25519
+}
+
+type Ed25519Verifier ed25519.PublicKey
+
+func (key Ed25519Verifier) Verify(digest, signature []byte) error {
+	if !ed25519.Verify(ed25519.PublicKey(key), digest, signature) {
+		return errVerification
+	}
+
+	return nil
+}
+
+func (Ed25519Verifier) Algorithm() Algorithm {
+	return AlgEd25519
+}
+
+type ECDSASigner ecdsa.PrivateKey
+
+func (key *ECDSASigner) Sign(digest []byte) (Signature, error) {
+	if key.Curve != elliptic.P256() {
+		return nil, errUnsupportedCurve
+	}
+
+	return ecdsa.SignASN1(rand.Reader, (*ecdsa.PrivateKey)(key), digest) //nolint:wrapcheck
+}
+
+func (*ECDSASigner) Algorithm() Algorithm {
+	return AlgECDSAP256
+}
+
+type ECDSAVerifier ecdsa.PublicKey
+
+func (key *ECDSAVerifier) Verify(digest, signature []byte) error {
+	if key.Curve != elliptic.P256() {
+		return errUnsupportedCurve
+	}
+
+	if !ecdsa.VerifyASN1((*ecdsa.PublicKey)(key), digest, signature) {
+		return errVerification
+	}
+
+	return nil
+}
+
+func (*ECDSAVerifier) Algorithm() Algorithm {
+	return AlgECDSAP256
+}
+
+type HMACSigner []byte
+
+func (key HMACSigner) Sign(digest []byte) (Signature, error) {
+	return hmacSum(key, digest), nil
+}
+
+func (HMACSigner) Algorithm() Algorithm {
+	return AlgHMACSHA256
+}
+
+type HMACVerifier []byte
+
+func (key HMACVerifier) Verify(digest, signature []byte) error {
+	if !hmac.Equal(hmacSum(key, digest), signature) {
+		return errVerification
+	}
+
+	return nil
+}
+
+func (HMACVerifier) Algorithm() Algorithm {
+	return AlgHMACSHA256
+}
+
+func hmacSum(key, digest []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(digest)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,116 @@
+package sign_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/http/sign"
+)
+
+type keyPair struct {
+	alg      sign.Algorithm
+	signer   sign.Signer
+	verifier sign.Verifier
+}
+
+func keyPairs() []keyPair {
+	rsaKey := mustOK(rsa.GenerateKey(rand.Reader, 2048))
+	edPub, edKey := mustOK2(ed25519.GenerateKey(rand.Reader))
+	ecKey := mustOK(ecdsa.GenerateKey(elliptic.P256(), rand.Reader))
+	hmacKey := []byte("secret")
+
+	return []keyPair{
+		{sign.AlgRSA, (*sign.RSASigner)(rsaKey), (*sign.RSAVerifier)(&rsaKey.PublicKey)},
+		{sign.AlgEd25519, sign.Ed25519Signer(edKey), sign.Ed25519Verifier(edPub)},
+		{sign.AlgECDSAP256, (*sign.ECDSASigner)(ecKey), (*sign.ECDSAVerifier)(&ecKey.PublicKey)},
+		{sign.AlgHMACSHA256, sign.HMACSigner(hmacKey), sign.HMACVerifier(hmacKey)},
+	}
+}
+
+func TestAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	moment := time.Unix(1_000_000, 0)
+	clock := sign.WithClock(func() time.Time { return moment })
+
+	pairs := keyPairs()
+
+	for i, kp := range pairs {
+		t.Run(kp.alg.String(), func(t *testing.T) {
+			t.Parallel()
+
+			for _, v := range []sign.Version{sign.V2, sign.V3, sign.V4} {
+				d := sign.NewDigest(v, moment)
+				d.AddString("abc")
+
+				str := mustOK(d.Sign(kp.signer)).HexString()
+
+				// RSA signatures keep their original format: single digit version
+				// followed by hex, others are hex-encoded including scheme byte.
+				if kp.alg == sign.AlgRSA {
+					assert.Equal(t, 1, len(str)%2)
+					assert.Equal(t, byte('0'+v), str[0])
+				} else {
+					assert.Equal(t, 0, len(str)%2)
+				}
+
+				sig := mustOK(sign.ParseSignature(str))
+
+				assert.Equal(t, v, sig.Ver())
+				assert.Equal(t, kp.alg, sig.Alg())
+				assert.Equal(t, moment, sig.Time())
+				assert.Equal(t, str, sig.HexString())
+
+				d = sign.NewDigest(sig.Ver(), sig.Time())
+				d.AddString("abc")
+
+				require.NoError(t, d.Verify(kp.verifier, sig, clock))
+
+				other := pairs[(i+1)%len(pairs)]
+				assert.ErrorIs(t, d.Verify(other.verifier, sig, clock), sign.ErrSignatureAlgorithmMismatch)
+
+				d = sign.NewDigest(sig.Ver(), sig.Time())
+				d.AddString("abd")
+
+				assert.ErrorIs(t, d.Verify(kp.verifier, sig, clock), sign.ErrSignatureMismatch)
+			}
+		})
+	}
+
+	t.Run("ECDSA requires P-256", func(t *testing.T) {
+		t.Parallel()
+
+		key := mustOK(ecdsa.GenerateKey(elliptic.P384(), rand.Reader))
+
+		_, err := sign.NewDigest(sign.V4, moment).Sign((*sign.ECDSASigner)(key))
+		assert.Error(t, err)
+
+		assert.Error(t, (*sign.ECDSAVerifier)(&key.PublicKey).Verify(nil, nil))
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sign.ParseSignature("f4" + strings.Repeat("00", 10))
+		assert.ErrorIs(t, err, sign.ErrorUnsupportedSignatureVersion)
+
+		assert.Equal(t, "unknown(15)", sign.Algorithm(15).String())
+	})
+}
+
+func mustOK2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
+	if err != nil {
+		panic(err)
+	}
+
+	return v1, v2
+}
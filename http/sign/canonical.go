@@ -0,0 +1,146 @@
+package sign
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// addCanonicalHead adds request parts preceding the body in the canonical form
+// used by V6 and later signatures: method, host, normalized path, sorted query
+// and values of signed headers, each followed by zero byte.
+//
+// Fails with [ErrInvalidQuery] in case the query can not be parsed, as its
+// malformed parts would not be covered by the signature otherwise.
+func (s *Digest) addCanonicalHead(r *http.Request) error {
+	var delimiter = []byte{0}
+
+	query, err := canonicalQuery(r)
+	if err != nil {
+		return err
+	}
+
+	s.AddString(r.Method)
+	s.AddBytes(delimiter)
+	s.AddString(host(r))
+	s.AddBytes(delimiter)
+	s.AddString(canonicalPath(r))
+	s.AddBytes(delimiter)
+	s.AddString(query)
+	s.AddBytes(delimiter)
+
+	for _, name := range s.headers {
+		s.AddString(headerValue(r, name))
+		s.AddBytes(delimiter)
+	}
+
+	return nil
+}
+
+// canonicalQuery returns the request query with parameters sorted by key.
+func canonicalQuery(r *http.Request) (string, error) {
+	query, err := url.ParseQuery(r.URL.RawQuery)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+
+	return query.Encode(), nil
+}
+
+// canonicalPath returns the request path with dot segments and duplicate
+// slashes removed. Trailing slash is preserved, as it is significant for most
+// routers.
+func canonicalPath(r *http.Request) string {
+	p := requestPath(r)
+
+	clean := path.Clean("/" + p)
+	if strings.HasSuffix(p, "/") && clean != "/" {
+		clean += "/"
+	}
+
+	return clean
+}
+
+// headerValue returns the value of the header to be signed. Multiple values
+// are joined with comma, surrounding whitespace is trimmed. Host and
+// Content-Length are taken from the request fields, as net/http does not keep
+// them in the header map consistently on client and server sides.
+func headerValue(r *http.Request, name string) string {
+	switch name {
+	case "host":
+		return host(r)
+	case "content-length":
+		if r.ContentLength <= 0 {
+			return ""
+		}
+
+		return strconv.FormatInt(r.ContentLength, 10)
+	}
+
+	values := r.Header.Values(name)
+	if len(values) == 1 {
+		return strings.TrimSpace(values[0])
+	}
+
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+
+	return strings.Join(trimmed, ",")
+}
+
+func joinHeaders(names []string) string {
+	return strings.Join(names, ",")
+}
+
+func splitHeaders(list string) []string {
+	if list == "" {
+		return nil
+	}
+
+	return strings.Split(list, ",")
+}
+
+// validHeaders reports whether names can be embedded into signature: they must
+// be unique lower-case header names, fitting into [MaxSignedHeadersLen] once
+// joined.
+func validHeaders(names []string) bool {
+	if len(joinHeaders(names)) > MaxSignedHeadersLen {
+		return false
+	}
+
+	for i, name := range names {
+		if !validHeaderName(name) {
+			return false
+		}
+
+		for _, prev := range names[:i] {
+			if prev == name {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, c := range []byte(name) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
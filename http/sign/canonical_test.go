@@ -0,0 +1,304 @@
+package sign_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/http/sign"
+)
+
+func TestV6(t *testing.T) {
+	t.Parallel()
+
+	pub, priv := mustOK2(ed25519.GenerateKey(rand.Reader))
+	signer := (*sign.Ed25519Signer)(&priv)
+	verifier := (*sign.Ed25519Verifier)(&pub)
+
+	moment := time.Unix(1_000_000, 0)
+	clock := sign.WithClock(func() time.Time { return moment })
+	headers := sign.WithSignedHeaders("Content-Type", "X-Tenant", "Host", "Content-Length")
+
+	sign6 := func(t *testing.T, r *http.Request) {
+		t.Helper()
+
+		digest := sign.NewDigest(sign.V6, moment, sign.WithKeyID("k1"), headers)
+		require.NoError(t, digest.AddRequest(r))
+
+		r.Header.Set(sign.DefaultHeader, mustOK(digest.Sign(signer)).HexString())
+	}
+
+	newRequest := func(target string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, target, strings.NewReader("payload"))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Add("X-Tenant", " a ")
+		r.Header.Add("X-Tenant", "b")
+
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T) *http.Request
+		opts    []sign.Option
+		wantErr error
+	}{
+		{
+			name: "valid",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path?b=2&a=1")
+				sign6(t, r)
+
+				return r
+			},
+		},
+		{
+			name: "query order and path form are not significant",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path?b=2&a=1")
+				sign6(t, r)
+
+				other := newRequest("http://example.com/./x/../path?a=1&b=%32")
+				other.Header.Set(sign.DefaultHeader, r.Header.Get(sign.DefaultHeader))
+
+				return other
+			},
+		},
+		{
+			name: "trailing slash is significant",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path")
+				sign6(t, r)
+
+				other := newRequest("http://example.com/path/")
+				other.Header.Set(sign.DefaultHeader, r.Header.Get(sign.DefaultHeader))
+
+				return other
+			},
+			wantErr: sign.ErrSignatureMismatch,
+		},
+		{
+			name: "malformed query",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path?a=1")
+				sign6(t, r)
+
+				// pairs with bad escapes or semicolons are dropped by parser.
+				other := newRequest("http://example.com/path?a=1&b=%zz;c")
+				other.Header.Set(sign.DefaultHeader, r.Header.Get(sign.DefaultHeader))
+
+				return other
+			},
+			wantErr: sign.ErrInvalidQuery,
+		},
+		{
+			name: "malformed query in streaming mode",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path?a=1")
+				sign6(t, r)
+
+				other := newRequest("http://example.com/path?a=1;b=2")
+				other.Header.Set(sign.DefaultHeader, r.Header.Get(sign.DefaultHeader))
+
+				return other
+			},
+			opts:    []sign.Option{sign.WithStreaming()},
+			wantErr: sign.ErrInvalidQuery,
+		},
+		{
+			name: "signed header changed",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path")
+				sign6(t, r)
+				r.Header.Set("Content-Type", "text/plain")
+
+				return r
+			},
+			wantErr: sign.ErrSignatureMismatch,
+		},
+		{
+			name: "signed header order changed",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path")
+				sign6(t, r)
+				r.Header.Del("X-Tenant")
+				r.Header.Add("X-Tenant", "b")
+				r.Header.Add("X-Tenant", "a")
+
+				return r
+			},
+			wantErr: sign.ErrSignatureMismatch,
+		},
+		{
+			name: "unsigned header changed",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path")
+				sign6(t, r)
+				r.Header.Set("X-Other", "value")
+
+				return r
+			},
+		},
+		{
+			name: "signed headers list tampered",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path")
+				sign6(t, r)
+
+				sig := mustOK(sign.ParseSignature(r.Header.Get(sign.DefaultHeader)))
+				hex := strings.Replace(sig.HexString(), "782d74656e616e74", "782d74656e616e75", 1) // x-tenant -> x-tenanu
+				r.Header.Set(sign.DefaultHeader, hex)
+
+				return r
+			},
+			wantErr: sign.ErrSignatureMismatch,
+		},
+		{
+			name: "required header is signed",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path")
+				sign6(t, r)
+
+				return r
+			},
+			opts: []sign.Option{sign.WithSignedHeaders("content-type")},
+		},
+		{
+			name: "required header is not signed",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path")
+				sign6(t, r)
+
+				return r
+			},
+			opts:    []sign.Option{sign.WithSignedHeaders("X-Request-Id")},
+			wantErr: sign.ErrUnsignedHeader,
+		},
+		{
+			name: "required header with older version",
+			prepare: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest("http://example.com/path")
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+
+				return r
+			},
+			opts:    []sign.Option{sign.WithSignedHeaders("content-type")},
+			wantErr: sign.ErrUnsignedHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := tt.prepare(t)
+
+			err := sign.VerifyRequest(r, verifier, append(tt.opts, clock)...)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "payload", string(mustOK(io.ReadAll(r.Body))))
+		})
+	}
+}
+
+func TestV6_MalformedQuery(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/path?a=%zz", nil)
+
+	err := sign.NewDigest(sign.V6, time.Unix(1_000_000, 0)).AddRequest(r)
+	require.ErrorIs(t, err, sign.ErrInvalidQuery)
+
+	// older versions sign the raw query, therefore it is not parsed.
+	require.NoError(t, sign.NewDigest(sign.V5, time.Unix(1_000_000, 0)).AddRequest(r))
+}
+
+func TestV6_Transport(t *testing.T) {
+	t.Parallel()
+
+	pub, priv := mustOK2(ed25519.GenerateKey(rand.Reader))
+	signer := (*sign.Ed25519Signer)(&priv)
+	verifier := (*sign.Ed25519Verifier)(&pub)
+
+	headers := sign.WithSignedHeaders("Content-Type", "Host", "Content-Length")
+
+	srv := httptest.NewServer(sign.NewMiddleware(verifier, headers)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(w, r.Body)
+		}),
+	))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{
+		Transport: sign.NewTransport(srv.Client().Transport, signer, sign.WithVersion(sign.V6), headers),
+	}
+
+	for _, body := range []io.Reader{nil, strings.NewReader("payload")} {
+		req := mustOK(http.NewRequest(http.MethodPost, srv.URL+"/path?b=2&a=1", body)) //nolint:noctx
+		req.Header.Set("Content-Type", "text/plain")
+
+		resp := mustOK(client.Do(req))
+		_ = resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestSignature_SignedHeaders(t *testing.T) {
+	t.Parallel()
+
+	_, priv := mustOK2(ed25519.GenerateKey(rand.Reader))
+	signer := (*sign.Ed25519Signer)(&priv)
+
+	digest := sign.NewDigest(sign.V6, time.Unix(1, 0), sign.WithKeyID("key"), sign.WithSignedHeaders("B", "a"))
+	sig := mustOK(sign.ParseSignature(mustOK(digest.Sign(signer)).HexString()))
+
+	assert.Equal(t, sign.V6, sig.Ver())
+	assert.Equal(t, "key", sig.KeyID())
+	assert.Equal(t, []string{"b", "a"}, sig.SignedHeaders())
+	assert.Len(t, sig.Data(), ed25519.SignatureSize)
+
+	noHeaders := mustOK(sign.NewDigest(sign.V6, time.Unix(1, 0)).Sign(signer))
+	assert.Empty(t, noHeaders.KeyID())
+	assert.Nil(t, noHeaders.SignedHeaders())
+	assert.Len(t, noHeaders.Data(), ed25519.SignatureSize)
+
+	// Malformed headers list: duplicates are not allowed.
+	dup := append(sig[:13:13], 3, 'a', ',', 'a')
+	dup = append(dup, sig.Data()...)
+	_, err := sign.ParseSignature(dup.HexString())
+	require.ErrorIs(t, err, sign.ErrInvalidSignature)
+
+	// Truncated headers list.
+	_, err = sign.ParseSignature(sig[:14].HexString())
+	require.ErrorIs(t, err, sign.ErrInvalidSignature)
+
+	assert.Panics(t, func() { sign.NewDigest(sign.V5, time.Now(), sign.WithSignedHeaders("a")) })
+	assert.Panics(t, func() { sign.NewDigest(sign.V6, time.Now(), sign.WithSignedHeaders("a", "A")) })
+	assert.Panics(t, func() { sign.NewDigest(sign.V6, time.Now(), sign.WithSignedHeaders("a b")) })
+	assert.Panics(t, func() { sign.NewDigest(sign.V6, time.Now(), sign.WithSignedHeaders(strings.Repeat("a", 256))) })
+}
@@ -0,0 +1,18 @@
+package sign
+
+import "net/http"
+
+// MessageSignatureBase builds RFC 9421 signature base of the request for given
+// signature input, which allows checking it against RFC test vectors covering
+// arbitrary components.
+func MessageSignatureBase(r *http.Request, input string) ([]byte, error) {
+	components, _, err := parseParams(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return signatureBase(r, components, input)
+}
+
+// MessageInput exposes messageInput for tests.
+var MessageInput = messageInput //nolint:gochecknoglobals
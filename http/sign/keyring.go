@@ -0,0 +1,184 @@
+package sign
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidKeyID   = errors.New("invalid key ID")
+	ErrDuplicateKeyID = errors.New("duplicate key ID")
+	ErrUnknownKey     = errors.New("unknown key")
+	ErrKeyInactive    = errors.New("key is not active")
+	ErrNoSigningKey   = errors.New("no active signing key")
+)
+
+// Key is a key registered in [Keyring].
+type Key struct {
+	// ID identifies the key, it is embedded into signatures produced with the
+	// key. Must be non-empty and not exceed [MaxKeyIDLen] bytes.
+	ID string
+	// Signer is used to sign requests, nil for keys used only for verification.
+	Signer Signer
+	// Verifier is used to verify signatures, nil for keys used only for signing.
+	Verifier Verifier
+	// NotBefore is the moment the key becomes active, zero value means the key
+	// is active since ever.
+	NotBefore time.Time
+	// NotAfter is the moment the key retires, zero value means the key never
+	// retires.
+	NotAfter time.Time
+}
+
+// ActiveAt reports whether the key is active at the given moment.
+func (k Key) ActiveAt(t time.Time) bool {
+	return (k.NotBefore.IsZero() || !t.Before(k.NotBefore)) &&
+		(k.NotAfter.IsZero() || t.Before(k.NotAfter))
+}
+
+// Keyring is a set of keys identified by their IDs, allowing to rotate keys
+// without a flag-day: new key can be added with activation time in the
+// future, while the old one is retired some time after, so verifiers accept
+// signatures produced by both keys during the overlap.
+//
+// Keyring is safe for concurrent use.
+type Keyring struct {
+	mu   sync.RWMutex `exhaustruct:"optional"`
+	keys map[string]Key
+}
+
+// NewKeyring creates a new [Keyring] containing provided keys.
+func NewKeyring(keys ...Key) (*Keyring, error) {
+	kr := &Keyring{
+		keys: make(map[string]Key, len(keys)),
+	}
+
+	for _, k := range keys {
+		if err := kr.Add(k); err != nil {
+			return nil, err
+		}
+	}
+
+	return kr, nil
+}
+
+// Add adds the key to the keyring. Returns [ErrInvalidKeyID] in case key ID is
+// empty or too long, and [ErrDuplicateKeyID] in case key with the same ID is
+// already present.
+func (kr *Keyring) Add(k Key) error {
+	if k.ID == "" || len(k.ID) > MaxKeyIDLen {
+		return fmt.Errorf("%w: %q", ErrInvalidKeyID, k.ID)
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	if _, ok := kr.keys[k.ID]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateKeyID, k.ID)
+	}
+
+	kr.keys[k.ID] = k
+
+	return nil
+}
+
+// Remove removes the key with the given ID from the keyring.
+func (kr *Keyring) Remove(id string) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	delete(kr.keys, id)
+}
+
+// SigningKey returns the key to sign with at the given moment: the most recently
+// activated one among active keys having a signer. Returns [ErrNoSigningKey]
+// if there is no such key.
+func (kr *Keyring) SigningKey(at time.Time) (Key, error) {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+
+	candidates := make([]Key, 0, len(kr.keys))
+
+	for _, k := range kr.keys {
+		if k.Signer != nil && k.ActiveAt(at) {
+			candidates = append(candidates, k)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Key{}, ErrNoSigningKey //nolint:exhaustruct
+	}
+
+	// key ID comparison makes choice deterministic for keys activated at the
+	// same moment.
+	return slices.MaxFunc(candidates, func(a, b Key) int {
+		if c := a.NotBefore.Compare(b.NotBefore); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	}), nil
+}
+
+// VerificationKey returns the key with the given ID to verify signature issued
+// at the given moment. Returns [ErrUnknownKey] in case there is no such key, or
+// it has no verifier, and [ErrKeyInactive] in case key was not active at the
+// given moment.
+func (kr *Keyring) VerificationKey(id string, at time.Time) (Key, error) {
+	kr.mu.RLock()
+	k, ok := kr.keys[id]
+	kr.mu.RUnlock()
+
+	if !ok || k.Verifier == nil {
+		return Key{}, fmt.Errorf("%w: %q", ErrUnknownKey, id) //nolint:exhaustruct
+	}
+
+	if !k.ActiveAt(at) {
+		return Key{}, fmt.Errorf("%w: %q", ErrKeyInactive, id) //nolint:exhaustruct
+	}
+
+	return k, nil
+}
+
+// Verify verifies the signature with [Digest.Verify] using the key picked by
+// the key ID embedded into signature, which must be active both at the moment
+// the signature was issued and at current time, so signatures of retired keys
+// are rejected whatever timestamp they carry. Signatures with zero timestamp
+// are always rejected with [ErrSignatureExpired], regardless of
+// [WithZeroTimestamps].
+func (kr *Keyring) Verify(d *Digest, sig Signature, opts ...Option) error {
+	return kr.verify(d, sig, newConfig(opts))
+}
+
+func (kr *Keyring) verify(d *Digest, sig Signature, cfg *config) error {
+	verifier, err := kr.resolve(sig.KeyID(), sig.Time(), cfg.now())
+	if err != nil {
+		return err
+	}
+
+	return d.verify(verifier, sig, cfg)
+}
+
+// resolve returns the verifier of the key the signature issued at given time
+// was produced with. The key must be active at the moment now as well, since
+// the signature timestamp is chosen by the signer.
+func (kr *Keyring) resolve(keyID string, issuedAt, now time.Time) (Verifier, error) {
+	if issuedAt.Unix() == 0 {
+		return nil, ErrSignatureExpired
+	}
+
+	k, err := kr.VerificationKey(keyID, issuedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !k.ActiveAt(now) {
+		return nil, fmt.Errorf("%w: %q", ErrKeyInactive, keyID)
+	}
+
+	return k.Verifier, nil
+}
@@ -0,0 +1,178 @@
+package sign_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/http/sign"
+)
+
+func TestKeyring(t *testing.T) {
+	t.Parallel()
+
+	moment := time.Unix(1_000_000, 0)
+
+	oldKey := sign.HMACSigner("old")
+	newKey := sign.HMACSigner("new")
+
+	// "old" key is retired in an hour, while "new" one is activated in half an
+	// hour, therefore both keys are active in between.
+	kr := mustOK(sign.NewKeyring(
+		sign.Key{
+			ID:       "old",
+			Signer:   oldKey,
+			Verifier: sign.HMACVerifier(oldKey),
+			NotAfter: moment.Add(time.Hour),
+		},
+		sign.Key{
+			ID:        "new",
+			Signer:    newKey,
+			Verifier:  sign.HMACVerifier(newKey),
+			NotBefore: moment.Add(30 * time.Minute),
+		},
+	))
+
+	t.Run("Add", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sign.NewKeyring(sign.Key{ID: ""}) //nolint:exhaustruct
+		require.ErrorIs(t, err, sign.ErrInvalidKeyID)
+
+		_, err = sign.NewKeyring(sign.Key{ID: strings.Repeat("k", 256)}) //nolint:exhaustruct
+		require.ErrorIs(t, err, sign.ErrInvalidKeyID)
+
+		_, err = sign.NewKeyring(sign.Key{ID: "k"}, sign.Key{ID: "k"}) //nolint:exhaustruct
+		require.ErrorIs(t, err, sign.ErrDuplicateKeyID)
+	})
+
+	t.Run("SigningKey", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "old", mustOK(kr.SigningKey(moment)).ID)
+		assert.Equal(t, "new", mustOK(kr.SigningKey(moment.Add(45*time.Minute))).ID, "newest key is preferred")
+		assert.Equal(t, "new", mustOK(kr.SigningKey(moment.Add(2*time.Hour))).ID)
+
+		_, err := mustOK(sign.NewKeyring()).SigningKey(moment)
+		assert.ErrorIs(t, err, sign.ErrNoSigningKey)
+	})
+
+	t.Run("VerificationKey", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "old", mustOK(kr.VerificationKey("old", moment.Add(45*time.Minute))).ID)
+		assert.Equal(t, "new", mustOK(kr.VerificationKey("new", moment.Add(45*time.Minute))).ID)
+
+		_, err := kr.VerificationKey("old", moment.Add(2*time.Hour))
+		require.ErrorIs(t, err, sign.ErrKeyInactive)
+
+		_, err = kr.VerificationKey("new", moment)
+		require.ErrorIs(t, err, sign.ErrKeyInactive)
+
+		_, err = kr.VerificationKey("unknown", moment)
+		require.ErrorIs(t, err, sign.ErrUnknownKey)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		t.Parallel()
+
+		kr := mustOK(sign.NewKeyring(sign.Key{ID: "k", Verifier: sign.HMACVerifier("k")})) //nolint:exhaustruct
+		kr.Remove("k")
+
+		_, err := kr.VerificationKey("k", moment)
+		require.ErrorIs(t, err, sign.ErrUnknownKey)
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		t.Parallel()
+
+		at := moment.Add(45 * time.Minute)
+		clock := sign.WithClock(func() time.Time { return at })
+
+		for _, id := range []string{"old", "new"} {
+			k := mustOK(kr.VerificationKey(id, at))
+
+			d := sign.NewDigest(sign.V5, at, sign.WithKeyID(id))
+			d.AddString("abc")
+
+			sig := mustOK(d.Sign(k.Signer))
+
+			d = sign.NewDigest(sign.V5, at, sign.WithKeyID(id))
+			d.AddString("abc")
+
+			assert.NoError(t, kr.Verify(d, sig, clock))
+		}
+
+		d := sign.NewDigest(sign.V5, at, sign.WithKeyID("unknown"))
+		sig := mustOK(d.Sign(oldKey))
+
+		assert.ErrorIs(t, kr.Verify(d, sig, clock), sign.ErrUnknownKey)
+	})
+
+	t.Run("transport and middleware", func(t *testing.T) {
+		t.Parallel()
+
+		var now atomic.Int64
+
+		clock := sign.WithClock(func() time.Time { return time.Unix(now.Load(), 0) })
+
+		srv := httptest.NewServer(sign.NewKeyringMiddleware(kr, clock)(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(w, r.Body)
+			}),
+		))
+		t.Cleanup(srv.Close)
+
+		client := &http.Client{Transport: sign.NewKeyringTransport(srv.Client().Transport, kr, clock)}
+
+		for _, at := range []time.Time{moment, moment.Add(45 * time.Minute), moment.Add(2 * time.Hour)} {
+			now.Store(at.Unix())
+
+			req := mustOK(http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))) //nolint:noctx
+			resp := mustOK(client.Do(req))
+			_ = resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+
+		at := moment.Add(2 * time.Hour)
+		now.Store(at.Unix())
+
+		// signature produced by the retired key is rejected.
+		req := httptest.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+		d := sign.NewDigest(sign.V5, at, sign.WithKeyID("old"))
+		require.NoError(t, d.AddRequest(req))
+		req.Header.Set(sign.DefaultHeader, mustOK(d.Sign(oldKey)).HexString())
+
+		assert.ErrorIs(t, kr.VerifyRequest(req, clock), sign.ErrKeyInactive)
+
+		// retired key is rejected even if signature claims it was issued while the
+		// key was active, including zero timestamp, which is never accepted.
+		for ts, wantErr := range map[time.Time]error{
+			moment:          sign.ErrKeyInactive,
+			time.Unix(0, 0): sign.ErrSignatureExpired,
+		} {
+			req = httptest.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+			d = sign.NewDigest(sign.V5, ts, sign.WithKeyID("old"))
+			require.NoError(t, d.AddRequest(req))
+			req.Header.Set(sign.DefaultHeader, mustOK(d.Sign(oldKey)).HexString())
+
+			err := kr.VerifyRequest(req, clock, sign.WithLeeway(3*time.Hour), sign.WithZeroTimestamps())
+			assert.ErrorIs(t, err, wantErr)
+		}
+
+		req = httptest.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+		signRequest(t, req, sign.V4, at, oldKey, sign.DefaultHeader)
+
+		assert.ErrorIs(t, kr.VerifyRequest(req, clock), sign.ErrUnknownKey, "V4 signatures have no key ID")
+
+		assert.Panics(t, func() { sign.NewKeyringTransport(nil, kr, sign.WithVersion(sign.V4)) })
+	})
+}
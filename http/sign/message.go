@@ -0,0 +1,642 @@
+package sign
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers used by RFC 9421 HTTP Message Signatures, see [WithMessageSignatures].
+const (
+	HeaderSignatureInput = "Signature-Input"
+	HeaderSignature      = "Signature"
+	HeaderContentDigest  = "Content-Digest"
+)
+
+// DefaultLabel is the default label of RFC 9421 message signature.
+const DefaultLabel = "sig1"
+
+// messageComponents are the derived components covered by every message
+// signature.
+var messageComponents = []string{"@method", "@authority", "@path", "@query"} //nolint:gochecknoglobals
+
+const contentDigestComponent = "content-digest"
+
+// SignMessage signs the request in place with RFC 9421 HTTP Message Signature
+// produced by signer, setting Signature-Input, Signature and, for requests with
+// body, Content-Digest headers.
+//
+// The signature covers @method, @authority, @path and @query derived
+// components, content-digest for requests with body and the headers set with
+// [WithSignedHeaders], which must be present in the request. Key ID, label and
+// clock are configured with [WithKeyID], [WithLabel] and [WithClock] options.
+//
+// The request body is read and replaced with an in-memory copy, unless
+// streaming mode is enabled with [WithStreaming] and request has GetBody set.
+func SignMessage(r *http.Request, signer Signer, opts ...Option) error {
+	cfg := newConfig(opts)
+
+	return signMessage(r, signer, cfg.keyID, cfg.now(), cfg)
+}
+
+// SignMessage is the same as [SignMessage], but signs the request with the key
+// picked by [Keyring.SigningKey], using its ID as keyid parameter.
+func (kr *Keyring) SignMessage(r *http.Request, opts ...Option) error {
+	cfg := newConfig(opts)
+	now := cfg.now()
+
+	key, err := kr.SigningKey(now)
+	if err != nil {
+		return err
+	}
+
+	return signMessage(r, key.Signer, key.ID, now, cfg)
+}
+
+func signMessage(r *http.Request, signer Signer, keyID string, now time.Time, cfg *config) error {
+	sum, err := contentDigest(r, cfg)
+	if err != nil {
+		return err
+	}
+
+	components := slices.Clone(messageComponents)
+
+	if sum != nil {
+		r.Header.Set(HeaderContentDigest, "sha-256=:"+base64.StdEncoding.EncodeToString(sum)+":")
+
+		components = append(components, contentDigestComponent)
+	}
+
+	for _, name := range cfg.signedHeaders {
+		if !slices.Contains(components, name) {
+			components = append(components, name)
+		}
+	}
+
+	alg := algorithmOf(signer)
+
+	params := serializeParams(components, now, keyID, alg)
+
+	base, err := signatureBase(r, components, params)
+	if err != nil {
+		return err
+	}
+
+	signature, err := signer.Sign(messageInput(alg, base))
+	if err != nil {
+		return fmt.Errorf("sign failed: %w", err)
+	}
+
+	if alg == AlgECDSAP256 {
+		if signature, err = ecdsaToFixed(signature); err != nil {
+			return fmt.Errorf("sign failed: %w", err)
+		}
+	}
+
+	r.Header.Set(HeaderSignatureInput, cfg.label+"="+params)
+	r.Header.Set(HeaderSignature, cfg.label+"=:"+base64.StdEncoding.EncodeToString(signature)+":")
+
+	return nil
+}
+
+// verifyMessage verifies RFC 9421 message signature of the request labeled
+// with configured label.
+func verifyMessage(r *http.Request, resolve resolveFunc, cfg *config) error {
+	msg, err := parseMessageSignature(r, cfg.label)
+	if err != nil {
+		return err
+	}
+
+	now := cfg.now()
+
+	verifier, err := resolve(msg.params["keyid"], msg.issuedAt, now)
+	if err != nil {
+		return err
+	}
+
+	alg := algorithmOf(verifier)
+
+	if name, ok := msg.params["alg"]; ok && name != algorithmName(alg) {
+		return ErrSignatureAlgorithmMismatch
+	}
+
+	if err = checkMessageTime(msg.params, msg.issuedAt, now, cfg.leeway); err != nil {
+		return err
+	}
+
+	if err = checkMessageCoverage(r, msg.components, cfg); err != nil {
+		return err
+	}
+
+	base, err := signatureBase(r, msg.components, msg.input)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+
+	if err = verifyMessageSignature(verifier, alg, base, msg.signature); err != nil {
+		return err
+	}
+
+	if cfg.replayGuard != nil {
+		// the signature itself is malleable, e.g. ECDSA one, therefore replays are
+		// identified by the signature base, which also covers its parameters.
+		return checkReplayOf(cfg.replayGuard, base, msg.issuedAt, now, cfg.leeway)
+	}
+
+	return nil
+}
+
+// messageSignature is the parsed RFC 9421 message signature along with its
+// signature input.
+type messageSignature struct {
+	input      string
+	signature  []byte
+	components []string
+	params     map[string]string
+	issuedAt   time.Time
+}
+
+// parseMessageSignature parses the message signature with given label and its
+// input from the request headers.
+func parseMessageSignature(r *http.Request, label string) (*messageSignature, error) {
+	input, ok := dictionaryMember(strings.Join(r.Header.Values(HeaderSignatureInput), ","), label)
+	if !ok {
+		return nil, ErrMissingSignature
+	}
+
+	rawSig, ok := dictionaryMember(strings.Join(r.Header.Values(HeaderSignature), ","), label)
+	if !ok {
+		return nil, ErrMissingSignature
+	}
+
+	signature, ok := parseByteSequence(rawSig)
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+
+	components, params, err := parseParams(input)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := strconv.ParseInt(params["created"], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	return &messageSignature{
+		input:      input,
+		signature:  signature,
+		components: components,
+		params:     params,
+		issuedAt:   time.Unix(created, 0),
+	}, nil
+}
+
+// checkMessageCoverage checks the content digest of the request and that the
+// signature covers all required components.
+func checkMessageCoverage(r *http.Request, components []string, cfg *config) error {
+	sum, err := contentDigest(r, cfg)
+	if err != nil {
+		return fmt.Errorf("read request body failed: %w", err)
+	}
+
+	required := slices.Clone(messageComponents)
+
+	if sum != nil || r.Header.Get(HeaderContentDigest) != "" {
+		required = append(required, contentDigestComponent)
+
+		if err = checkContentDigest(r, sum); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range append(required, cfg.signedHeaders...) {
+		if !slices.Contains(components, name) {
+			return fmt.Errorf("%w: %s", ErrUnsignedHeader, name)
+		}
+	}
+
+	return nil
+}
+
+// verifyMessageSignature performs cryptographic check of the signature against
+// the signature base.
+func verifyMessageSignature(verifier Verifier, alg Algorithm, base, signature []byte) error {
+	var err error
+
+	if alg == AlgECDSAP256 {
+		if signature, err = ecdsaFromFixed(signature); err != nil {
+			return fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+		}
+	}
+
+	if err = verifier.Verify(messageInput(alg, base), signature); err != nil {
+		return fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+
+	return nil
+}
+
+func checkMessageTime(params map[string]string, issuedAt, now time.Time, leeway time.Duration) error {
+	if dt := issuedAt.Sub(now); dt < -leeway || dt > leeway {
+		return ErrSignatureExpired
+	}
+
+	if v, ok := params["expires"]; ok {
+		expires, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+
+		if now.Unix() > expires {
+			return ErrSignatureExpired
+		}
+	}
+
+	return nil
+}
+
+// contentDigest returns SHA-256 sum of the request body, or nil for requests
+// without body. Body is hashed from GetBody in streaming mode, otherwise it is
+// buffered.
+func contentDigest(r *http.Request, cfg *config) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	h := sha256.New()
+
+	if cfg.streaming && r.GetBody != nil {
+		d := &Digest{h: h} //nolint:exhaustruct
+
+		if err := addGetBody(d, r, cfg.maxBodySize); err != nil {
+			return nil, err
+		}
+	} else {
+		body, err := getBody(r, cfg.maxBodySize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(body) == 0 {
+			return nil, nil
+		}
+
+		_, _ = h.Write(body)
+	}
+
+	return h.Sum(nil), nil
+}
+
+func checkContentDigest(r *http.Request, sum []byte) error {
+	if sum == nil {
+		sum = sha256.New().Sum(nil)
+	}
+
+	member, ok := dictionaryMember(strings.Join(r.Header.Values(HeaderContentDigest), ","), "sha-256")
+	if !ok {
+		return fmt.Errorf("%w: sha-256 content digest is missing", ErrSignatureMismatch)
+	}
+
+	expected, ok := parseByteSequence(member)
+	if !ok || subtle.ConstantTimeCompare(expected, sum) != 1 {
+		return fmt.Errorf("%w: content digest mismatch", ErrSignatureMismatch)
+	}
+
+	return nil
+}
+
+// signatureBase builds the signature base of the request as described in
+// RFC 9421 section 2.5.
+func signatureBase(r *http.Request, components []string, params string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, name := range components {
+		value, err := componentValue(r, name)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteString(strconv.Quote(name))
+		buf.WriteString(": ")
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteString(`"@signature-params": `)
+	buf.WriteString(params)
+
+	return buf.Bytes(), nil
+}
+
+func componentValue(r *http.Request, name string) (string, error) {
+	switch name {
+	case "@method":
+		return strings.ToUpper(r.Method), nil
+	case "@authority":
+		return authority(r), nil
+	case "@path":
+		if p := r.URL.EscapedPath(); p != "" {
+			return p, nil
+		}
+
+		return "/", nil
+	case "@query":
+		return "?" + r.URL.RawQuery, nil
+	}
+
+	if strings.HasPrefix(name, "@") {
+		return "", fmt.Errorf("unsupported component %q", name)
+	}
+
+	values := r.Header.Values(name)
+	if len(values) == 0 {
+		return "", fmt.Errorf("header %q is missing", name)
+	}
+
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+
+	return strings.Join(trimmed, ", "), nil
+}
+
+// authority returns the normalized authority of the request as described in
+// RFC 9421 section 2.2.3: the host is lowercased and the default port of the
+// request scheme is omitted.
+func authority(r *http.Request) string {
+	h := strings.ToLower(host(r))
+
+	scheme := r.URL.Scheme
+	if scheme == "" {
+		scheme = "http"
+
+		if r.TLS != nil {
+			scheme = "https"
+		}
+	}
+
+	switch {
+	case strings.EqualFold(scheme, "http"):
+		return strings.TrimSuffix(h, ":80")
+	case strings.EqualFold(scheme, "https"):
+		return strings.TrimSuffix(h, ":443")
+	default:
+		return h
+	}
+}
+
+// messageInput returns the data passed to signer or verifier. Ed25519 and
+// HMAC-SHA256 sign the signature base itself, while RSA and ECDSA sign its
+// SHA-256 hash.
+func messageInput(alg Algorithm, base []byte) []byte {
+	switch alg {
+	case AlgEd25519, AlgHMACSHA256:
+		return base
+	default:
+		sum := sha256.Sum256(base)
+
+		return sum[:]
+	}
+}
+
+func algorithmName(alg Algorithm) string {
+	switch alg {
+	case AlgRSA:
+		return "rsa-v1_5-sha256"
+	case AlgEd25519:
+		return "ed25519"
+	case AlgECDSAP256:
+		return "ecdsa-p256-sha256"
+	case AlgHMACSHA256:
+		return "hmac-sha256"
+	default:
+		return ""
+	}
+}
+
+func serializeParams(components []string, created time.Time, keyID string, alg Algorithm) string {
+	quoted := make([]string, len(components))
+	for i, c := range components {
+		quoted[i] = strconv.Quote(c)
+	}
+
+	params := "(" + strings.Join(quoted, " ") + ");created=" + strconv.FormatInt(created.Unix(), 10)
+
+	if keyID != "" {
+		params += ";keyid=" + strconv.Quote(keyID)
+	}
+
+	return params + ";alg=" + strconv.Quote(algorithmName(alg))
+}
+
+// dictionaryMember returns the raw value of structured field dictionary member
+// with given key.
+func dictionaryMember(dict, key string) (string, bool) {
+	for dict != "" {
+		member, rest := splitMember(dict)
+		dict = rest
+
+		name, value, ok := strings.Cut(strings.TrimSpace(member), "=")
+		if ok && name == key {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// splitMember splits the first top-level member of structured field list or
+// dictionary off the rest, taking quoted strings into account.
+func splitMember(s string) (string, string) {
+	quoted := false
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == ',':
+			return s[:i], s[i+1:]
+		}
+	}
+
+	return s, ""
+}
+
+func parseByteSequence(s string) ([]byte, bool) {
+	if len(s) < 2 || s[0] != ':' || s[len(s)-1] != ':' {
+		return nil, false
+	}
+
+	data, err := base64.StdEncoding.DecodeString(s[1 : len(s)-1])
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// parseParams parses signature parameters: inner list of covered components
+// followed by parameters. Component parameters are not supported.
+func parseParams(s string) ([]string, map[string]string, error) {
+	components, rest, err := parseComponents(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params, err := parseParamList(rest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return components, params, nil
+}
+
+// parseComponents parses the inner list of covered components at the beginning
+// of s, returning the components and the rest of s.
+func parseComponents(s string) ([]string, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, "", ErrInvalidSignature
+	}
+
+	s = s[1:]
+
+	var components []string
+
+	for {
+		s = strings.TrimLeft(s, " ")
+
+		if strings.HasPrefix(s, ")") {
+			return components, s[1:], nil
+		}
+
+		name, rest, ok := parseString(s)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != ')') {
+			return nil, "", ErrInvalidSignature
+		}
+
+		components = append(components, name)
+		s = rest
+	}
+}
+
+// parseParamList parses parameters in the ";key=value" form.
+func parseParamList(s string) (map[string]string, error) {
+	params := make(map[string]string)
+
+	for s != "" {
+		if s[0] != ';' {
+			return nil, ErrInvalidSignature
+		}
+
+		key, rest, _ := strings.Cut(s[1:], "=")
+
+		value, rest, ok := parseParamValue(rest)
+		if !ok {
+			return nil, ErrInvalidSignature
+		}
+
+		params[key] = value
+		s = rest
+	}
+
+	return params, nil
+}
+
+// parseParamValue parses quoted or bare parameter value at the beginning of s,
+// returning the value and the rest of s.
+func parseParamValue(s string) (string, string, bool) {
+	if strings.HasPrefix(s, `"`) {
+		return parseString(s)
+	}
+
+	end := strings.IndexByte(s, ';')
+	if end < 0 {
+		end = len(s)
+	}
+
+	return s[:end], s[end:], true
+}
+
+// parseString parses quoted string at the beginning of s, returning its
+// unescaped value and the rest of s.
+func parseString(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", "", false
+	}
+
+	var b strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 == len(s) {
+				return "", "", false
+			}
+
+			i++
+			b.WriteByte(s[i])
+		case '"':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return "", "", false
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+const p256ScalarSize = 32
+
+var errInvalidECDSASignature = errors.New("invalid ECDSA signature")
+
+// ecdsaToFixed converts ASN.1 encoded ECDSA signature to the concatenation of
+// fixed size r and s used by RFC 9421.
+func ecdsaToFixed(sig []byte) ([]byte, error) {
+	var s ecdsaSignature
+
+	if rest, err := asn1.Unmarshal(sig, &s); err != nil || len(rest) != 0 {
+		return nil, errInvalidECDSASignature
+	}
+
+	if s.R.BitLen() > p256ScalarSize*8 || s.S.BitLen() > p256ScalarSize*8 {
+		return nil, errInvalidECDSASignature
+	}
+
+	out := make([]byte, 2*p256ScalarSize)
+	s.R.FillBytes(out[:p256ScalarSize])
+	s.S.FillBytes(out[p256ScalarSize:])
+
+	return out, nil
+}
+
+// ecdsaFromFixed converts the concatenation of fixed size r and s to ASN.1
+// encoded ECDSA signature.
+func ecdsaFromFixed(sig []byte) ([]byte, error) {
+	if len(sig) != 2*p256ScalarSize {
+		return nil, errInvalidECDSASignature
+	}
+
+	return asn1.Marshal(ecdsaSignature{ //nolint:wrapcheck
+		R: new(big.Int).SetBytes(sig[:p256ScalarSize]),
+		S: new(big.Int).SetBytes(sig[p256ScalarSize:]),
+	})
+}
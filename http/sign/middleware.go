@@ -0,0 +1,233 @@
+package sign
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+var (
+	ErrMissingSignature = errors.New("missing signature")
+	ErrUnsignedHeader   = errors.New("required header is not signed")
+)
+
+// ErrorHandler responds to the request that failed signature verification.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// DefaultErrorHandler responds with 401 Unauthorized in case signature is
+// missing, expired, replayed, does not match the request, does not cover
+// required headers or its key is unknown or inactive, with 413 Request Entity
+// Too Large in case body exceeds the limit, and with 400 Bad Request otherwise
+// (malformed signature, unsupported version, unreadable body).
+func DefaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error) {
+	code := http.StatusBadRequest
+
+	if errors.Is(err, ErrBodyTooLarge) {
+		code = http.StatusRequestEntityTooLarge
+	} else if errors.Is(err, ErrMissingSignature) ||
+		errors.Is(err, ErrSignatureExpired) ||
+		errors.Is(err, ErrSignatureMismatch) ||
+		errors.Is(err, ErrSignatureReplayed) ||
+		errors.Is(err, ErrUnsignedHeader) ||
+		errors.Is(err, ErrUnknownKey) ||
+		errors.Is(err, ErrKeyInactive) {
+		code = http.StatusUnauthorized
+	}
+
+	http.Error(w, http.StatusText(code), code)
+}
+
+// NewMiddleware returns net/http middleware that verifies signatures of
+// incoming requests using [VerifyRequest] and passes only properly signed
+// requests to the next handler. Failed requests are passed to the error handler
+// (see [WithErrorHandler]).
+//
+// The request body stays readable for the next handler. In streaming mode (see
+// [WithStreaming]) the next handler is responsible for reading the body to
+// EOF, which completes the verification.
+func NewMiddleware(verifier Verifier, opts ...Option) func(http.Handler) http.Handler {
+	return middleware(staticResolver(verifier), newConfig(opts))
+}
+
+// NewKeyringMiddleware is the same as [NewMiddleware], but verifies signatures
+// using the key from keyring, see [Keyring.Verify].
+func NewKeyringMiddleware(kr *Keyring, opts ...Option) func(http.Handler) http.Handler {
+	return middleware(kr.resolve, newConfig(opts))
+}
+
+// resolveFunc returns the verifier for the signature produced with the key of
+// given ID at given time, which is verified at the moment now.
+type resolveFunc func(keyID string, issuedAt, now time.Time) (Verifier, error)
+
+func staticResolver(verifier Verifier) resolveFunc {
+	return func(string, time.Time, time.Time) (Verifier, error) {
+		return verifier, nil
+	}
+}
+
+func middleware(resolve resolveFunc, cfg *config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := verifyRequest(r, resolve, cfg); err != nil {
+				cfg.errorHandler(w, r, err)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// VerifyRequest verifies the signature of incoming request. The signature is
+// read from the header (see [WithHeader]), digest is rebuilt from the request
+// according to signature version and checked with [Digest.Verify]. Headers
+// that must be covered by the signature are set with [WithSignedHeaders].
+//
+// The request body is consumed and replaced with an in-memory copy, so it can be
+// read again after the call. In streaming mode (see [WithStreaming]) the body is
+// replaced with the reader that completes verification upon reaching EOF.
+func VerifyRequest(r *http.Request, verifier Verifier, opts ...Option) error {
+	return verifyRequest(r, staticResolver(verifier), newConfig(opts))
+}
+
+// VerifyRequest is the same as [VerifyRequest], but verifies signature using
+// the key from keyring, see [Keyring.Verify].
+func (kr *Keyring) VerifyRequest(r *http.Request, opts ...Option) error {
+	return verifyRequest(r, kr.resolve, newConfig(opts))
+}
+
+func verifyRequest(r *http.Request, resolve resolveFunc, cfg *config) error {
+	if cfg.messages {
+		return verifyMessage(r, resolve, cfg)
+	}
+
+	sig, err := requestSignature(r, cfg)
+	if err != nil {
+		return err
+	}
+
+	verifier, err := resolve(sig.KeyID(), sig.Time(), cfg.now())
+	if err != nil {
+		return err
+	}
+
+	digest := newDigest(sig.Ver(), sig.Time(), sig.KeyID(), sig.SignedHeaders())
+
+	if cfg.streaming && r.Body != nil && r.Body != http.NoBody {
+		return verifyStreaming(r, digest, verifier, sig, cfg)
+	}
+
+	if err = digest.addRequest(r, cfg.maxBodySize); err != nil {
+		return fmt.Errorf("build request digest failed: %w", err)
+	}
+
+	return digest.verify(verifier, sig, cfg)
+}
+
+// requestSignature parses the signature of the request and checks that it
+// covers the required headers.
+func requestSignature(r *http.Request, cfg *config) (Signature, error) {
+	hdr := r.Header.Get(cfg.header)
+	if hdr == "" {
+		return nil, ErrMissingSignature
+	}
+
+	sig, err := ParseSignature(hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := sig.SignedHeaders()
+
+	for _, name := range cfg.signedHeaders {
+		if !slices.Contains(signed, name) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsignedHeader, name)
+		}
+	}
+
+	return sig, nil
+}
+
+// verifyStreaming checks the signature parameters and replaces the request
+// body with the reader that completes verification upon reaching EOF.
+func verifyStreaming(r *http.Request, digest *Digest, verifier Verifier, sig Signature, cfg *config) error {
+	now := cfg.now()
+
+	if err := digest.checkParams(verifier, sig, now, cfg); err != nil {
+		return err
+	}
+
+	if cfg.maxBodySize > 0 && r.ContentLength > cfg.maxBodySize {
+		return ErrBodyTooLarge
+	}
+
+	if err := digest.addRequestHead(r); err != nil {
+		return fmt.Errorf("build request digest failed: %w", err)
+	}
+
+	r.Body = &verifyingBody{
+		body:      r.Body,
+		digest:    digest,
+		remaining: cfg.maxBodySize,
+		limited:   cfg.maxBodySize > 0,
+		err:       nil,
+		verify: func() error {
+			return digest.checkSignature(verifier, sig, now, cfg)
+		},
+	}
+
+	return nil
+}
+
+// verifyingBody hashes the request body while it is being read and verifies
+// the signature upon reaching EOF, returning verification error instead of
+// [io.EOF] in case of failure.
+type verifyingBody struct {
+	body      io.ReadCloser
+	digest    *Digest
+	remaining int64
+	limited   bool
+	verify    func() error
+	// err is the terminal error returned by all subsequent reads.
+	err error
+}
+
+func (b *verifyingBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	n, err := b.body.Read(p)
+
+	b.digest.AddBytes(p[:n])
+
+	if b.limited {
+		b.remaining -= int64(n)
+
+		if b.remaining < 0 {
+			b.err = ErrBodyTooLarge
+
+			return n, b.err
+		}
+	}
+
+	if errors.Is(err, io.EOF) {
+		b.err = io.EOF
+
+		if verr := b.verify(); verr != nil {
+			b.err = verr
+		}
+
+		return n, b.err
+	}
+
+	return n, err //nolint:wrapcheck
+}
+
+func (b *verifyingBody) Close() error {
+	return b.body.Close() //nolint:wrapcheck
+}
@@ -0,0 +1,197 @@
+package sign_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/http/sign"
+)
+
+func signRequest(t *testing.T, r *http.Request, v sign.Version, ts time.Time, signer sign.Signer, header string) {
+	t.Helper()
+
+	digest := sign.NewDigest(v, ts)
+	require.NoError(t, digest.AddRequest(r))
+
+	r.Header.Set(header, mustOK(digest.Sign(signer)).HexString())
+}
+
+func TestNewMiddleware(t *testing.T) {
+	t.Parallel()
+
+	key := mustOK(rsa.GenerateKey(rand.Reader, 2048))
+	signer := (*sign.RSASigner)(key)
+	verifier := (*sign.RSAVerifier)(&key.PublicKey)
+
+	moment := time.Unix(1_000_000, 0)
+	clock := sign.WithClock(func() time.Time { return moment })
+
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(body)
+	})
+
+	newRequest := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "http://example.com/path?b=2&a=1", strings.NewReader("payload"))
+	}
+
+	tests := []struct {
+		name     string
+		prepare  func(t *testing.T, r *http.Request)
+		opts     []sign.Option
+		wantCode int
+	}{
+		{
+			name: "v4",
+			prepare: func(t *testing.T, r *http.Request) {
+				t.Helper()
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "v3",
+			prepare: func(t *testing.T, r *http.Request) {
+				t.Helper()
+				signRequest(t, r, sign.V3, moment, signer, sign.DefaultHeader)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "v2",
+			prepare: func(t *testing.T, r *http.Request) {
+				t.Helper()
+				signRequest(t, r, sign.V2, moment, signer, sign.DefaultHeader)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "custom header",
+			prepare: func(t *testing.T, r *http.Request) {
+				t.Helper()
+				signRequest(t, r, sign.V4, moment, signer, "X-Custom")
+			},
+			opts:     []sign.Option{sign.WithHeader("X-Custom")},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "missing signature",
+			prepare:  func(*testing.T, *http.Request) {},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "malformed signature",
+			prepare: func(_ *testing.T, r *http.Request) {
+				r.Header.Set(sign.DefaultHeader, "4zz")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "unsupported version",
+			prepare: func(_ *testing.T, r *http.Request) {
+				r.Header.Set(sign.DefaultHeader, "911111111111111110000")
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "expired",
+			prepare: func(t *testing.T, r *http.Request) {
+				t.Helper()
+				signRequest(t, r, sign.V4, moment.Add(-time.Hour), signer, sign.DefaultHeader)
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "tampered path",
+			prepare: func(t *testing.T, r *http.Request) {
+				t.Helper()
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+				r.URL.Path = "/other"
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "tampered body",
+			prepare: func(t *testing.T, r *http.Request) {
+				t.Helper()
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+				r.Body = io.NopCloser(strings.NewReader("tampered"))
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newRequest()
+			tt.prepare(t, r)
+
+			w := httptest.NewRecorder()
+
+			sign.NewMiddleware(verifier, append([]sign.Option{clock}, tt.opts...)...)(echo).ServeHTTP(w, r)
+
+			require.Equal(t, tt.wantCode, w.Code)
+
+			if tt.wantCode == http.StatusOK {
+				assert.Equal(t, "payload", w.Body.String(), "body must be re-readable by the next handler")
+			}
+		})
+	}
+
+	t.Run("custom error handler", func(t *testing.T) {
+		t.Parallel()
+
+		var got error
+
+		handler := sign.WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+			got = err
+
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		w := httptest.NewRecorder()
+
+		sign.NewMiddleware(verifier, handler)(echo).ServeHTTP(w, newRequest())
+
+		assert.Equal(t, http.StatusTeapot, w.Code)
+		assert.ErrorIs(t, got, sign.ErrMissingSignature)
+	})
+}
+
+func TestVerifyRequest(t *testing.T) {
+	t.Parallel()
+
+	key := mustOK(rsa.GenerateKey(rand.Reader, 2048))
+	moment := time.Unix(1_000_000, 0)
+
+	r := httptest.NewRequest(http.MethodPut, "http://example.com/", errReader{})
+	r.Header.Set(sign.DefaultHeader, "40000000000000000")
+
+	err := sign.VerifyRequest(r, (*sign.RSAVerifier)(&key.PublicKey), sign.WithClock(func() time.Time { return moment }))
+	assert.ErrorIs(t, err, errRead)
+}
+
+var errRead = errors.New("read failed")
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
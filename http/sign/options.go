@@ -0,0 +1,186 @@
+package sign
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultLeeway is the default maximum allowed difference between signature
+// timestamp and current time during verification.
+const DefaultLeeway = 5 * time.Minute
+
+// DefaultHeader is the default name of HTTP header carrying request signature.
+const DefaultHeader = "X-Signature"
+
+// Option is a functional option for configuring signing and verification of
+// signatures.
+type Option func(*config)
+
+type config struct {
+	leeway        time.Duration
+	zeroTimestamp bool
+	now           func() time.Time
+	header        string
+	errorHandler  ErrorHandler
+	version       Version
+	keyID         string
+	replayGuard   ReplayGuard
+	maxBodySize   int64
+	streaming     bool
+	signedHeaders []string
+	messages      bool
+	label         string
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		leeway:       DefaultLeeway,
+		now:          time.Now,
+		header:       DefaultHeader,
+		errorHandler: DefaultErrorHandler,
+		version:      V4,
+		label:        DefaultLabel,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithLeeway sets the maximum allowed difference between signature timestamp
+// and current time. Signatures issued outside of this window are rejected with
+// [ErrSignatureExpired].
+func WithLeeway(leeway time.Duration) Option {
+	return func(cfg *config) {
+		cfg.leeway = leeway
+	}
+}
+
+// WithZeroTimestamps makes verification accept signatures with zero timestamp
+// at any moment, as produced by legacy signers. By default such signatures are
+// rejected with [ErrSignatureExpired].
+//
+// Signatures with zero timestamp never expire, while replay guard remembers
+// them only for the leeway, so they can be replayed afterwards. The option has
+// no effect on [Keyring] verification, which always rejects them.
+func WithZeroTimestamps() Option {
+	return func(cfg *config) {
+		cfg.zeroTimestamp = true
+	}
+}
+
+// WithClock sets the function used to obtain current time, [time.Now] is used
+// by default.
+func WithClock(now func() time.Time) Option {
+	return func(cfg *config) {
+		cfg.now = now
+	}
+}
+
+// WithHeader sets the name of HTTP header carrying request signature,
+// [DefaultHeader] is used by default.
+func WithHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.header = name
+	}
+}
+
+// WithErrorHandler sets the handler used by middleware to respond to requests
+// that failed signature verification, [DefaultErrorHandler] is used by default.
+func WithErrorHandler(fn ErrorHandler) Option {
+	return func(cfg *config) {
+		cfg.errorHandler = fn
+	}
+}
+
+// WithVersion sets the version of signatures produced by [Transport], V4 is used
+// by default.
+func WithVersion(v Version) Option {
+	return func(cfg *config) {
+		cfg.version = v
+	}
+}
+
+// WithKeyID sets the ID of the key embedded into produced signatures. Key ID is
+// supported by V5 and later signatures and must not exceed [MaxKeyIDLen] bytes.
+func WithKeyID(id string) Option {
+	return func(cfg *config) {
+		cfg.keyID = id
+	}
+}
+
+// WithReplayGuard sets the guard used to reject signatures that were already
+// accepted once within the leeway, see [ReplayGuard].
+func WithReplayGuard(g ReplayGuard) Option {
+	return func(cfg *config) {
+		cfg.replayGuard = g
+	}
+}
+
+// WithMaxBodySize sets the maximum size of request body that can be signed or
+// verified, requests with bigger bodies fail with [ErrBodyTooLarge] as soon as
+// the limit is exceeded, without buffering the rest of the body. Zero, which is
+// the default, means there is no limit.
+func WithMaxBodySize(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxBodySize = n
+	}
+}
+
+// WithStreaming enables streaming mode, in which request body is hashed while
+// being read instead of being buffered in memory.
+//
+// On the server side the signature parameters (version, algorithm, timestamp
+// and key) are checked upfront, while the cryptographic check is postponed
+// until the request body is read to EOF by the handler. In case of failure the
+// read returns the verification error instead of [io.EOF]. Therefore, handler
+// MUST read the body entirely and treat read errors as verification failures
+// before acting upon the request. Requests without body are verified upfront.
+//
+// On the client side [Transport] uses GetBody of the request to hash the body
+// prior to sending it, keeping memory usage bounded. Requests without GetBody
+// are buffered anyway, since the signature has to be sent before the body.
+func WithStreaming() Option {
+	return func(cfg *config) {
+		cfg.streaming = true
+	}
+}
+
+// WithSignedHeaders sets the names of headers covered by produced signatures in
+// the order they are signed. Names are case-insensitive, they are embedded into
+// signature in lower case. Signed headers are supported by V6 and later
+// signatures, the joined list must not exceed [MaxSignedHeadersLen] bytes.
+//
+// On the verification side the option sets headers that must be covered by the
+// signature, signatures missing any of them are rejected with
+// [ErrUnsignedHeader].
+func WithSignedHeaders(names ...string) Option {
+	return func(cfg *config) {
+		cfg.signedHeaders = make([]string, len(names))
+
+		for i, name := range names {
+			cfg.signedHeaders[i] = strings.ToLower(name)
+		}
+	}
+}
+
+// WithMessageSignatures switches [Transport], middleware and [VerifyRequest] to
+// RFC 9421 HTTP Message Signatures carried in Signature-Input and Signature
+// headers instead of the native format, see [SignMessage]. Signature version
+// and header options do not apply to message signatures. Incoming requests are
+// always buffered to check their Content-Digest.
+func WithMessageSignatures() Option {
+	return func(cfg *config) {
+		cfg.messages = true
+	}
+}
+
+// WithLabel sets the label of RFC 9421 message signature to produce or verify,
+// [DefaultLabel] is used by default.
+func WithLabel(label string) Option {
+	return func(cfg *config) {
+		cfg.label = label
+	}
+}
@@ -0,0 +1,138 @@
+package sign
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+var ErrSignatureReplayed = errors.New("signature replayed")
+
+// ReplayGuard remembers accepted signatures in order to reject their reuse.
+//
+// Signature is considered valid for the leeway duration around its timestamp,
+// therefore guard is required to remember it only for that period of time. This
+// allows implementations backed by external stores with expiring keys.
+//
+// Signatures are identified by the signed data rather than by the signature
+// itself, since some algorithms, such as ECDSA, produce malleable signatures:
+// the same data may be validly signed in several ways without the key. As a
+// consequence, identical requests signed within the same second are considered
+// replayed.
+type ReplayGuard interface {
+	// Seen records id as seen until expiresAt and reports whether it was already
+	// recorded and not yet expired at the moment now.
+	Seen(id string, now, expiresAt time.Time) (bool, error)
+}
+
+// checkReplay checks the replay of signature of the digest with given sum. The
+// signature data is not taken into account as it may be malleable, while its
+// header, holding the version, timestamp, key ID and signed headers, is.
+func checkReplay(g ReplayGuard, sig Signature, sum []byte, now time.Time, leeway time.Duration) error {
+	return checkReplayOf(g, slices.Concat(sig[:sig.dataOffset()], sum), sig.Time(), now, leeway)
+}
+
+// checkReplayOf checks the replay of signed data issued at ts. The data must be
+// the one covered by the signature, not the signature itself.
+func checkReplayOf(g ReplayGuard, data []byte, ts, now time.Time, leeway time.Duration) error {
+	// signatures with zero timestamp are accepted at any moment when allowed by
+	// WithZeroTimestamps, therefore the best we can do is to remember them for the leeway from now.
+	if ts.Unix() == 0 {
+		ts = now
+	}
+
+	id := sha256.Sum256(data)
+
+	seen, err := g.Seen(string(id[:]), now, ts.Add(leeway))
+	if err != nil {
+		return fmt.Errorf("check signature replay failed: %w", err)
+	}
+
+	if seen {
+		return ErrSignatureReplayed
+	}
+
+	return nil
+}
+
+// MemoryReplayGuard is an in-memory [ReplayGuard] implementation, which keeps
+// ids until they expire. In case capacity is exceeded the least recently seen
+// ids are evicted, even if they're not expired, which makes them replayable,
+// therefore capacity should be chosen according to the expected request rate
+// and leeway.
+//
+// MemoryReplayGuard is safe for concurrent use.
+type MemoryReplayGuard struct {
+	mu       sync.Mutex `exhaustruct:"optional"`
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List
+}
+
+type replayEntry struct {
+	id        string
+	expiresAt time.Time
+}
+
+// NewMemoryReplayGuard creates a new [MemoryReplayGuard] holding up to capacity
+// ids. Capacity less or equal to zero means there is no limit.
+func NewMemoryReplayGuard(capacity int) *MemoryReplayGuard {
+	return &MemoryReplayGuard{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Seen implements [ReplayGuard].
+func (g *MemoryReplayGuard) Seen(id string, now, expiresAt time.Time) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if el, ok := g.entries[id]; ok {
+		entry := el.Value.(*replayEntry)
+		seen := now.Before(entry.expiresAt)
+
+		if expiresAt.After(entry.expiresAt) {
+			entry.expiresAt = expiresAt
+		}
+
+		g.lru.MoveToFront(el)
+
+		return seen, nil
+	}
+
+	g.evict(now)
+
+	g.entries[id] = g.lru.PushFront(&replayEntry{id: id, expiresAt: expiresAt})
+
+	return false, nil
+}
+
+// Len returns the number of remembered ids, including expired ones that were
+// not evicted yet.
+func (g *MemoryReplayGuard) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.lru.Len()
+}
+
+// evict removes expired entries from the tail of the list and the least
+// recently seen ones in case capacity is reached.
+func (g *MemoryReplayGuard) evict(now time.Time) {
+	for el := g.lru.Back(); el != nil; el = g.lru.Back() {
+		entry := el.Value.(*replayEntry)
+
+		if now.Before(entry.expiresAt) && (g.capacity <= 0 || g.lru.Len() < g.capacity) {
+			return
+		}
+
+		g.lru.Remove(el)
+		delete(g.entries, entry.id)
+	}
+}
@@ -0,0 +1,199 @@
+package sign_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/http/sign"
+)
+
+func TestMemoryReplayGuard(t *testing.T) {
+	t.Parallel()
+
+	moment := time.Unix(1_000_000, 0)
+
+	t.Run("expiration", func(t *testing.T) {
+		t.Parallel()
+
+		g := sign.NewMemoryReplayGuard(0)
+
+		assert.False(t, mustOK(g.Seen("a", moment, moment.Add(time.Minute))))
+		assert.True(t, mustOK(g.Seen("a", moment.Add(time.Second), moment.Add(time.Minute))))
+		assert.False(t, mustOK(g.Seen("b", moment, moment.Add(time.Minute))))
+
+		assert.False(t, mustOK(g.Seen("a", moment.Add(time.Minute), moment.Add(2*time.Minute))), "expired")
+		assert.True(t, mustOK(g.Seen("a", moment.Add(time.Minute), moment.Add(2*time.Minute))))
+
+		// "b" expired and evicted upon insertion of new entry.
+		assert.False(t, mustOK(g.Seen("c", moment.Add(time.Minute), moment.Add(2*time.Minute))))
+		assert.Equal(t, 2, g.Len())
+	})
+
+	t.Run("capacity", func(t *testing.T) {
+		t.Parallel()
+
+		g := sign.NewMemoryReplayGuard(2)
+		exp := moment.Add(time.Minute)
+
+		assert.False(t, mustOK(g.Seen("a", moment, exp)))
+		assert.False(t, mustOK(g.Seen("b", moment, exp)))
+		assert.True(t, mustOK(g.Seen("a", moment, exp)))
+
+		// "b" is the least recently seen, therefore it is evicted.
+		assert.False(t, mustOK(g.Seen("c", moment, exp)))
+		assert.Equal(t, 2, g.Len())
+
+		assert.True(t, mustOK(g.Seen("a", moment, exp)))
+		assert.True(t, mustOK(g.Seen("c", moment, exp)))
+		assert.False(t, mustOK(g.Seen("b", moment, exp)))
+	})
+}
+
+type replayGuardFunc func(id string, now, expiresAt time.Time) (bool, error)
+
+func (fn replayGuardFunc) Seen(id string, now, expiresAt time.Time) (bool, error) {
+	return fn(id, now, expiresAt)
+}
+
+func TestWithReplayGuard(t *testing.T) {
+	t.Parallel()
+
+	signer := sign.HMACSigner("secret")
+	verifier := sign.HMACVerifier("secret")
+
+	moment := time.Unix(1_000_000, 0)
+	clock := sign.WithClock(func() time.Time { return moment })
+
+	newRequest := func(body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "http://example.com/", strings.NewReader(body))
+		signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+
+		return r
+	}
+
+	t.Run("middleware", func(t *testing.T) {
+		t.Parallel()
+
+		mw := sign.NewMiddleware(verifier, clock, sign.WithReplayGuard(sign.NewMemoryReplayGuard(10)))
+		handler := mw(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+		r := newRequest("payload")
+
+		for _, code := range []int{http.StatusOK, http.StatusUnauthorized, http.StatusUnauthorized} {
+			w := httptest.NewRecorder()
+
+			req := r.Clone(r.Context())
+			req.Body = mustOK(r.GetBody())
+
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, code, w.Code)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("other payload"))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		t.Parallel()
+
+		var expiresAt time.Time
+
+		guard := replayGuardFunc(func(_ string, _, exp time.Time) (bool, error) {
+			expiresAt = exp
+
+			return false, nil
+		})
+
+		assert.NoError(t, sign.VerifyRequest(newRequest("payload"), verifier, clock,
+			sign.WithLeeway(time.Minute), sign.WithReplayGuard(guard)))
+		assert.Equal(t, moment.Add(time.Minute), expiresAt)
+	})
+
+	t.Run("guard error", func(t *testing.T) {
+		t.Parallel()
+
+		errGuard := errors.New("guard failed") //nolint:err113
+
+		guard := replayGuardFunc(func(string, time.Time, time.Time) (bool, error) {
+			return false, errGuard
+		})
+
+		err := sign.VerifyRequest(newRequest("payload"), verifier, clock, sign.WithReplayGuard(guard))
+		assert.ErrorIs(t, err, errGuard)
+		assert.NotErrorIs(t, err, sign.ErrSignatureReplayed)
+	})
+
+	t.Run("not consulted for invalid signatures", func(t *testing.T) {
+		t.Parallel()
+
+		guard := replayGuardFunc(func(string, time.Time, time.Time) (bool, error) {
+			t.Fatal("guard must not be called")
+
+			return false, nil
+		})
+
+		r := newRequest("payload")
+		r.Body = http.NoBody
+
+		err := sign.VerifyRequest(r, verifier, clock, sign.WithReplayGuard(guard))
+		assert.ErrorIs(t, err, sign.ErrSignatureMismatch)
+	})
+}
+
+func TestWithReplayGuard_MalleatedSignature(t *testing.T) {
+	t.Parallel()
+
+	key := mustOK(ecdsa.GenerateKey(elliptic.P256(), rand.Reader))
+	verifier := (*sign.ECDSAVerifier)(&key.PublicKey)
+
+	moment := time.Unix(1_000_000, 0)
+	opts := []sign.Option{
+		sign.WithClock(func() time.Time { return moment }),
+		sign.WithReplayGuard(sign.NewMemoryReplayGuard(10)),
+	}
+
+	newDigest := func() *sign.Digest {
+		d := sign.NewDigest(sign.V4, moment)
+		d.AddString("payload")
+
+		return d
+	}
+
+	sig := mustOK(newDigest().Sign((*sign.ECDSASigner)(key)))
+
+	require.NoError(t, newDigest().Verify(verifier, sig, opts...))
+	require.ErrorIs(t, newDigest().Verify(verifier, sig, opts...), sign.ErrSignatureReplayed)
+
+	malleated := append(sig[:len(sig)-len(sig.Data()):len(sig)-len(sig.Data())], malleateECDSA(t, sig.Data())...)
+	require.NotEqual(t, sig, malleated)
+
+	assert.ErrorIs(t, newDigest().Verify(verifier, malleated, opts...), sign.ErrSignatureReplayed)
+}
+
+// malleateECDSA returns another valid ASN.1 encoded ECDSA P-256 signature of the
+// same data, with s replaced by N-s.
+func malleateECDSA(t *testing.T, sig []byte) []byte {
+	t.Helper()
+
+	var rs struct{ R, S *big.Int }
+
+	_, err := asn1.Unmarshal(sig, &rs)
+	require.NoError(t, err)
+
+	rs.S.Sub(elliptic.P256().Params().N, rs.S)
+
+	return mustOK(asn1.Marshal(rs))
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"hash"
 	"io"
+	"math"
 	"net/http"
 	"slices"
 	"time"
@@ -21,6 +22,12 @@ type Version int
 var (
 	ErrInvalidSignature              = errors.New("invalid signature")
 	ErrorUnsupportedSignatureVersion = errors.New("unsupported signature version")
+	ErrSignatureVersionMismatch      = errors.New("signature version mismatch")
+	ErrSignatureExpired              = errors.New("signature expired")
+	ErrSignatureMismatch             = errors.New("signature mismatch")
+	ErrSignatureAlgorithmMismatch    = errors.New("signature algorithm mismatch")
+	ErrBodyTooLarge                  = errors.New("request body too large")
+	ErrInvalidQuery                  = errors.New("invalid request query")
 )
 
 const (
@@ -29,32 +36,98 @@ const (
 	V2
 	V3
 	V4
+	// V5 is V4 with key ID embedded into the signature, see [WithKeyID].
+	V5
+	// V6 is V5 with canonical request form: the path is normalized, query
+	// parameters are sorted and the headers listed in the signature are signed
+	// as well, see [WithSignedHeaders].
+	V6
 )
 
+// MaxKeyIDLen is the maximum length of key ID embedded into signature.
+const MaxKeyIDLen = math.MaxUint8
+
+// MaxSignedHeadersLen is the maximum length of comma-separated list of signed
+// header names embedded into signature.
+const MaxSignedHeadersLen = math.MaxUint8
+
+func (v Version) valid() bool {
+	return v >= V2 && v <= V6
+}
+
 type Digest struct {
-	Ver Version
-	ts  [8]byte
-	h   hash.Hash
+	Ver     Version
+	ts      [8]byte
+	keyID   string
+	headers []string
+	h       hash.Hash
 }
 
-func NewDigest(v Version, timeStamp time.Time) *Digest {
-	if v < V2 || v > V4 {
-		panic("unsupported version")
-	}
+// NewDigest creates a new digest of given version and timestamp. Signatures of
+// V5 and later also carry the key ID, which is set with [WithKeyID] option.
+// Signatures of V6 and later also carry the list of signed headers, which is
+// set with [WithSignedHeaders] option.
+//
+// Panics if version is not supported, or key ID or signed headers are set for
+// version that does not support them.
+func NewDigest(v Version, timeStamp time.Time, opts ...Option) *Digest {
+	cfg := newConfig(opts)
+
+	return newDigest(v, timeStamp, cfg.keyID, cfg.signedHeaders)
+}
+
+func newDigest(v Version, timeStamp time.Time, keyID string, headers []string) *Digest {
+	validateDigestParams(v, keyID, headers)
 
 	s := &Digest{
-		Ver: v,
-		h:   sha256.New(),
-		ts:  [8]byte{},
+		Ver:     v,
+		h:       sha256.New(),
+		ts:      [8]byte{},
+		keyID:   keyID,
+		headers: headers,
 	}
 
 	binary.BigEndian.PutUint64(s.ts[:], uint64(timeStamp.Unix())) //nolint:gosec
 
 	_, _ = s.h.Write(s.ts[:])
 
+	if v >= V5 {
+		_, _ = s.h.Write([]byte{byte(len(s.keyID))})
+		_, _ = s.h.Write([]byte(s.keyID))
+	}
+
+	if v >= V6 {
+		list := joinHeaders(s.headers)
+
+		_, _ = s.h.Write([]byte{byte(len(list))})
+		_, _ = s.h.Write([]byte(list))
+	}
+
 	return s
 }
 
+func validateDigestParams(v Version, keyID string, headers []string) {
+	if !v.valid() {
+		panic("unsupported version")
+	}
+
+	if keyID != "" && v < V5 {
+		panic("key ID is not supported by signature version")
+	}
+
+	if len(keyID) > MaxKeyIDLen {
+		panic("key ID is too long")
+	}
+
+	if len(headers) > 0 && v < V6 {
+		panic("signed headers are not supported by signature version")
+	}
+
+	if !validHeaders(headers) {
+		panic("invalid signed headers")
+	}
+}
+
 func (s *Digest) AddBytes(data []byte) {
 	if len(data) == 0 {
 		return
@@ -67,26 +140,82 @@ func (s *Digest) AddString(str string) {
 	s.AddBytes([]byte(str))
 }
 
+// Write implements [io.Writer] by adding p to the digest, it never fails.
+func (s *Digest) Write(p []byte) (int, error) {
+	s.AddBytes(p)
+
+	return len(p), nil
+}
+
+// AddReader adds everything read from r until EOF to the digest without
+// buffering it, returning the number of bytes read.
+func (s *Digest) AddReader(r io.Reader) (int64, error) {
+	return io.Copy(s, r) //nolint:wrapcheck
+}
+
+// AddRequest adds the request to the digest according to digest version. The
+// request body is read entirely and replaced with an in-memory copy, so it can
+// be read again. V6 and later digests fail with [ErrInvalidQuery] in case the
+// request query is malformed.
 func (s *Digest) AddRequest(r *http.Request) error {
-	body, err := getBody(r)
+	return s.addRequest(r, 0)
+}
+
+func (s *Digest) addRequest(r *http.Request, maxBodySize int64) error {
+	body, err := getBody(r, maxBodySize)
 	if err != nil {
 		return fmt.Errorf("read request body failed: %w", err)
 	}
 
-	if s.Ver == V4 {
+	if err = s.addRequestHead(r); err != nil {
+		return err
+	}
+
+	s.AddBytes(body)
+
+	return nil
+}
+
+// addRequestHead adds request parts preceding the body.
+func (s *Digest) addRequestHead(r *http.Request) error {
+	if s.Ver >= V6 {
+		return s.addCanonicalHead(r)
+	}
+
+	if s.Ver >= V4 {
 		var delimiter = []byte{0}
 
 		s.AddString(r.Method)
 		s.AddBytes(delimiter)
-		s.AddString(r.Host)
+		s.AddString(host(r))
 		s.AddBytes(delimiter)
-		s.AddString(r.URL.Path)
+		s.AddString(requestPath(r))
 		s.AddBytes(delimiter)
 		s.AddString(r.URL.RawQuery)
 		s.AddBytes(delimiter)
 	}
 
-	s.AddBytes(body)
+	return nil
+}
+
+// addBody adds everything read from r to the digest, failing with
+// [ErrBodyTooLarge] once more than maxBodySize bytes were read. Zero
+// maxBodySize means there is no limit.
+func (s *Digest) addBody(r io.Reader, maxBodySize int64) error {
+	if maxBodySize <= 0 {
+		_, err := s.AddReader(r)
+
+		return err
+	}
+
+	n, err := s.AddReader(io.LimitReader(r, maxBodySize+1))
+	if err != nil {
+		return err
+	}
+
+	if n > maxBodySize {
+		return ErrBodyTooLarge
+	}
 
 	return nil
 }
@@ -101,50 +230,209 @@ func (s *Digest) Sign(signer Signer) (Signature, error) {
 		return nil, fmt.Errorf("sign failed: %w", err)
 	}
 
-	data := make([]byte, hdrSize+len(signature))
+	list := joinHeaders(s.headers)
+	data := make([]byte, hdrSize, hdrSize+2+len(s.keyID)+len(list)+len(signature))
 
-	data[0] = byte(s.Ver)
+	data[0] = scheme(s.Ver, algorithmOf(signer))
 	copy(data[timeOffset:], s.ts[:])
-	copy(data[signOffset:], signature)
+
+	if s.Ver >= V5 {
+		data = append(data, byte(len(s.keyID)))
+		data = append(data, s.keyID...)
+	}
+
+	if s.Ver >= V6 {
+		data = append(data, byte(len(list)))
+		data = append(data, list...)
+	}
+
+	data = append(data, signature...)
 
 	return data, nil
 }
 
-func getBody(r *http.Request) ([]byte, error) {
-	body, err := io.ReadAll(r.Body)
+// Verify checks that the signature was produced for the digest using the key
+// behind verifier.
+//
+// Prior to cryptographic check it ensures that the signature version matches
+// the digest one, the signature algorithm matches the verifier one and that the
+// signature was issued within the leeway around current time (see [WithLeeway]
+// and [WithClock]), signatures with zero timestamp are rejected unless
+// [WithZeroTimestamps] is set. Each failure is reported with its own error:
+// [ErrorUnsupportedSignatureVersion] or [ErrSignatureVersionMismatch],
+// [ErrSignatureAlgorithmMismatch], [ErrSignatureExpired] and
+// [ErrSignatureMismatch]. In case replay guard is set with [WithReplayGuard],
+// reused signatures are rejected with [ErrSignatureReplayed].
+func (s *Digest) Verify(verifier Verifier, sig Signature, opts ...Option) error {
+	return s.verify(verifier, sig, newConfig(opts))
+}
+
+func (s *Digest) verify(verifier Verifier, sig Signature, cfg *config) error {
+	now := cfg.now()
+
+	if err := s.checkParams(verifier, sig, now, cfg); err != nil {
+		return err
+	}
+
+	return s.checkSignature(verifier, sig, now, cfg)
+}
+
+// checkParams checks signature version, algorithm and timestamp, which can be
+// done before the digest is complete.
+func (s *Digest) checkParams(verifier Verifier, sig Signature, now time.Time, cfg *config) error {
+	switch sig.Ver() {
+	case VErr:
+		return ErrorUnsupportedSignatureVersion
+	case s.Ver:
+	default:
+		return ErrSignatureVersionMismatch
+	}
+
+	if sig.Alg() != algorithmOf(verifier) {
+		return ErrSignatureAlgorithmMismatch
+	}
+
+	if sig.Time().Unix() == 0 && !cfg.zeroTimestamp {
+		return ErrSignatureExpired
+	}
+
+	if !sig.IssuedAt(now, cfg.leeway) {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+// checkSignature performs cryptographic check of the signature against the
+// complete digest and checks it for replay.
+func (s *Digest) checkSignature(verifier Verifier, sig Signature, now time.Time, cfg *config) error {
+	sum := s.Sum(nil)
+
+	if err := verifier.Verify(sum, sig.Data()); err != nil {
+		return fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+
+	if cfg.replayGuard != nil {
+		return checkReplay(cfg.replayGuard, sig, sum, now, cfg.leeway)
+	}
+
+	return nil
+}
+
+// getBody reads the request body and replaces it with an in-memory copy, which
+// also becomes available via GetBody. In case maxBodySize is positive and body
+// exceeds it, [ErrBodyTooLarge] is returned.
+func getBody(r *http.Request, maxBodySize int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if maxBodySize > 0 && r.ContentLength > maxBodySize {
+		return nil, ErrBodyTooLarge
+	}
+
+	reader := io.Reader(r.Body)
+	if maxBodySize > 0 {
+		reader = io.LimitReader(r.Body, maxBodySize+1)
+	}
+
+	body, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, err //nolint:wrapcheck
 	}
 
+	if maxBodySize > 0 && int64(len(body)) > maxBodySize {
+		return nil, ErrBodyTooLarge
+	}
+
 	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
 
 	return body, nil
 }
 
+// requestPath returns the path of the request. Outgoing requests may have empty
+// path, which is sent as "/", therefore it is signed the same way.
+func requestPath(r *http.Request) string {
+	if r.URL.Path == "" {
+		return "/"
+	}
+
+	return r.URL.Path
+}
+
+// host returns the host the request is addressed to. Outgoing requests may have
+// Host unset, in which case the URL host is used, same as http.Client does.
+func host(r *http.Request) string {
+	if r.Host != "" {
+		return r.Host
+	}
+
+	return r.URL.Host
+}
+
 const (
 	timeOffset = 1
 	signOffset = 9
 	hdrSize    = 1 + 8
 )
 
+// Signature is a binary signature representation. The first byte holds the
+// signature scheme: version in the low nibble and algorithm in the high one,
+// followed by the timestamp and the signature data.
 type Signature []byte
 
+// ParseSignature parses the signature from its string representation, see
+// [Signature.HexString] for the format description.
 func ParseSignature(s string) (Signature, error) {
-	if len(s) < hdrSize || len(s)%2 != 1 {
+	if len(s) < hdrSize {
+		return nil, ErrInvalidSignature
+	}
+
+	data, err := decodeSignature(s)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := Signature(data)
+
+	if len(data) < hdrSize || sig.dataOffset() < 0 {
 		return nil, ErrInvalidSignature
 	}
 
-	v := Version(s[0] - '0')
-	if v < V2 || v > V4 {
+	if sig.Ver() == VErr || !sig.Alg().valid() {
 		return nil, ErrorUnsupportedSignatureVersion
 	}
 
-	data := make([]byte, (len(s)-1)>>1+1)
+	if !validHeaders(sig.SignedHeaders()) {
+		return nil, ErrInvalidSignature
+	}
 
-	data[0] = byte(v)
+	return sig, nil
+}
 
-	_, err := hex.Decode(data[timeOffset:], []byte(s[timeOffset:]))
-	if err != nil {
+// decodeSignature decodes the string representation of the signature, see
+// [Signature.HexString].
+func decodeSignature(s string) ([]byte, error) {
+	if len(s)%2 == 0 {
+		data, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("decode signature failed: %w", err)
+		}
+
+		return data, nil
+	}
+
+	if s[0] < '0' || s[0] > '9' {
+		return nil, ErrorUnsupportedSignatureVersion
+	}
+
+	data := make([]byte, (len(s)-1)>>1+1)
+	data[0] = s[0] - '0'
+
+	if _, err := hex.Decode(data[timeOffset:], []byte(s[timeOffset:])); err != nil {
 		return nil, fmt.Errorf("decode signature failed: %w", err)
 	}
 
@@ -180,8 +468,8 @@ func (s Signature) Ver() Version {
 		return VErr
 	}
 
-	v := Version(s[0])
-	if v < V2 || v > V4 {
+	v := Version(s[0] & versionMask)
+	if !v.valid() {
 		return VErr
 	}
 
@@ -197,11 +485,77 @@ func (s Signature) Time() time.Time {
 }
 
 func (s Signature) Data() []byte {
-	if len(s) < hdrSize {
+	off := s.dataOffset()
+	if off < 0 {
+		return nil
+	}
+
+	return s[off:]
+}
+
+// KeyID returns the ID of the key used to produce the signature. Only V5 and
+// later signatures carry key ID, for others empty string is returned.
+func (s Signature) KeyID() string {
+	if s.Ver() < V5 || s.dataOffset() < 0 {
+		return ""
+	}
+
+	return string(s[hdrSize+1 : s.headersOffset()])
+}
+
+// SignedHeaders returns names of the headers covered by the signature in the
+// order they were signed. Only V6 and later signatures carry signed headers, for
+// others nil is returned.
+func (s Signature) SignedHeaders() []string {
+	if s.Ver() < V6 || s.dataOffset() < 0 {
 		return nil
 	}
 
-	return s[hdrSize:]
+	off := s.headersOffset()
+
+	return splitHeaders(string(s[off+1 : s.dataOffset()]))
+}
+
+// headersOffset returns the offset of signed headers list of V6 signatures.
+// It must only be called for signatures of V5 and later.
+func (s Signature) headersOffset() int {
+	if len(s) <= hdrSize {
+		return -1
+	}
+
+	return hdrSize + 1 + int(s[hdrSize])
+}
+
+// dataOffset returns the offset of signature data, or -1 if signature is
+// malformed.
+func (s Signature) dataOffset() int {
+	if len(s) < hdrSize {
+		return -1
+	}
+
+	if s.Ver() < V5 {
+		return hdrSize
+	}
+
+	off := s.headersOffset()
+	if off < 0 || off > len(s) {
+		return -1
+	}
+
+	if s.Ver() < V6 {
+		return off
+	}
+
+	if off == len(s) {
+		return -1
+	}
+
+	off += 1 + int(s[off])
+	if off > len(s) {
+		return -1
+	}
+
+	return off
 }
 
 // IssuedAt checks if the signature was issued at the specified time.
@@ -219,11 +573,31 @@ func (s Signature) IssuedAt(t time.Time, leeway time.Duration) bool {
 	return dt >= -leeway && dt <= leeway
 }
 
+// Alg returns the algorithm used to produce the signature.
+func (s Signature) Alg() Algorithm {
+	if len(s) == 0 {
+		return AlgRSA
+	}
+
+	return Algorithm(s[0] >> algorithmShift)
+}
+
+// HexString returns the string representation of the signature.
+//
+// RSA signatures are represented as a single decimal digit of version followed
+// by hex-encoded timestamp and data, which gives a string of odd length. This
+// is the original format and it is kept for compatibility. Signatures produced
+// by other algorithms are entirely hex-encoded, including the scheme byte,
+// therefore their representation is of even length.
 func (s Signature) HexString() string {
 	if len(s) == 0 {
 		return ""
 	}
 
+	if s.Alg() != AlgRSA {
+		return hex.EncodeToString(s)
+	}
+
 	data := make([]byte, len(s)*2-1) // (len(s)-1)*2 + 1
 
 	data[0] = '0' + s[0]
@@ -241,3 +615,21 @@ type RSASigner rsa.PrivateKey
 func (key *RSASigner) Sign(digest []byte) (Signature, error) {
 	return rsa.SignPKCS1v15(nil, (*rsa.PrivateKey)(key), crypto.SHA256, digest) //nolint:wrapcheck
 }
+
+func (*RSASigner) Algorithm() Algorithm {
+	return AlgRSA
+}
+
+type Verifier interface {
+	Verify(digest, signature []byte) error
+}
+
+type RSAVerifier rsa.PublicKey
+
+func (key *RSAVerifier) Verify(digest, signature []byte) error {
+	return rsa.VerifyPKCS1v15((*rsa.PublicKey)(key), crypto.SHA256, digest, signature) //nolint:wrapcheck
+}
+
+func (*RSAVerifier) Algorithm() Algorithm {
+	return AlgRSA
+}
@@ -5,6 +5,8 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/binary"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -181,7 +183,7 @@ func TestParseSignature(t *testing.T) {
 		},
 		{
 			name:    "invalid version",
-			s:       "511111111111111110000",
+			s:       "911111111111111110000",
 			wantErr: true,
 		},
 		{
@@ -226,6 +228,46 @@ func TestParseSignature(t *testing.T) {
 	assert.Equal(t, sign.V3, s.Ver())
 	assert.Equal(t, time.Unix(32, 0), s.Time())
 	assert.Equal(t, []byte{2, 3, 4, 5, 6}, s.Data())
+	assert.Empty(t, s.KeyID())
+
+	s, err = sign.ParseSignature("50000000000000020036b6579010203")
+
+	assert.NoError(t, err)
+	assert.Equal(t, sign.V5, s.Ver())
+	assert.Equal(t, time.Unix(32, 0), s.Time())
+	assert.Equal(t, "key", s.KeyID())
+	assert.Equal(t, []byte{1, 2, 3}, s.Data())
+
+	_, err = sign.ParseSignature("50000000000000020046b6579")
+	assert.ErrorIs(t, err, sign.ErrInvalidSignature, "key ID length exceeds signature")
+
+	_, err = sign.ParseSignature("50000000000000020")
+	assert.ErrorIs(t, err, sign.ErrInvalidSignature, "key ID length is missing")
+}
+
+func TestNewDigest(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { sign.NewDigest(sign.VErr, time.Now()) })
+	assert.Panics(t, func() { sign.NewDigest(sign.V4, time.Now(), sign.WithKeyID("key")) })
+	assert.Panics(t, func() { sign.NewDigest(sign.V5, time.Now(), sign.WithKeyID(strings.Repeat("k", 256))) })
+
+	key := mustOK(rsa.GenerateKey(rand.Reader, 2048))
+	moment := time.Unix(1_000_000, 0)
+
+	d := sign.NewDigest(sign.V5, moment, sign.WithKeyID("key"))
+	d.AddString("abc")
+
+	sig := mustOK(sign.ParseSignature(mustOK(d.Sign((*sign.RSASigner)(key))).HexString()))
+
+	assert.Equal(t, "key", sig.KeyID())
+
+	// key ID is a part of the signed digest.
+	d = sign.NewDigest(sign.V5, moment, sign.WithKeyID("other"))
+	d.AddString("abc")
+
+	assert.ErrorIs(t, d.Verify((*sign.RSAVerifier)(&key.PublicKey), sig, sign.WithLeeway(math.MaxInt64)),
+		sign.ErrSignatureMismatch)
 }
 
 func TestSignature_IssuedAt(t *testing.T) {
@@ -275,6 +317,153 @@ func TestDigest_Sign(t *testing.T) {
 	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest.Sum(nil), s2.Data()))
 }
 
+func TestDigest_Verify(t *testing.T) {
+	t.Parallel()
+
+	key := mustOK(rsa.GenerateKey(rand.Reader, 2048))
+	otherKey := mustOK(rsa.GenerateKey(rand.Reader, 2048))
+
+	moment := time.Unix(1_000_000, 0)
+
+	digest := sign.NewDigest(sign.V4, moment)
+	digest.AddString("abc")
+
+	sig := mustOK(digest.Sign((*sign.RSASigner)(key)))
+
+	tests := []struct {
+		name     string
+		ver      sign.Version
+		body     string
+		verifier sign.Verifier
+		sig      sign.Signature
+		now      time.Time
+		wantErr  error
+	}{
+		{
+			name:     "ok",
+			ver:      sign.V4,
+			body:     "abc",
+			verifier: (*sign.RSAVerifier)(&key.PublicKey),
+			sig:      sig,
+			now:      moment,
+			wantErr:  nil,
+		},
+		{
+			name:     "ok within leeway",
+			ver:      sign.V4,
+			body:     "abc",
+			verifier: (*sign.RSAVerifier)(&key.PublicKey),
+			sig:      sig,
+			now:      moment.Add(-time.Minute),
+			wantErr:  nil,
+		},
+		{
+			name:     "unsupported version",
+			ver:      sign.V4,
+			body:     "abc",
+			verifier: (*sign.RSAVerifier)(&key.PublicKey),
+			sig:      nil,
+			now:      moment,
+			wantErr:  sign.ErrorUnsupportedSignatureVersion,
+		},
+		{
+			name:     "version mismatch",
+			ver:      sign.V3,
+			body:     "abc",
+			verifier: (*sign.RSAVerifier)(&key.PublicKey),
+			sig:      sig,
+			now:      moment,
+			wantErr:  sign.ErrSignatureVersionMismatch,
+		},
+		{
+			name:     "expired",
+			ver:      sign.V4,
+			body:     "abc",
+			verifier: (*sign.RSAVerifier)(&key.PublicKey),
+			sig:      sig,
+			now:      moment.Add(time.Hour),
+			wantErr:  sign.ErrSignatureExpired,
+		},
+		{
+			name:     "issued in future",
+			ver:      sign.V4,
+			body:     "abc",
+			verifier: (*sign.RSAVerifier)(&key.PublicKey),
+			sig:      sig,
+			now:      moment.Add(-time.Hour),
+			wantErr:  sign.ErrSignatureExpired,
+		},
+		{
+			name:     "body mismatch",
+			ver:      sign.V4,
+			body:     "abd",
+			verifier: (*sign.RSAVerifier)(&key.PublicKey),
+			sig:      sig,
+			now:      moment,
+			wantErr:  sign.ErrSignatureMismatch,
+		},
+		{
+			name:     "key mismatch",
+			ver:      sign.V4,
+			body:     "abc",
+			verifier: (*sign.RSAVerifier)(&otherKey.PublicKey),
+			sig:      sig,
+			now:      moment,
+			wantErr:  sign.ErrSignatureMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := sign.NewDigest(tt.ver, moment)
+			d.AddString(tt.body)
+
+			err := d.Verify(tt.verifier, tt.sig, sign.WithClock(func() time.Time { return tt.now }))
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("leeway", func(t *testing.T) {
+		t.Parallel()
+
+		d := sign.NewDigest(sign.V4, moment)
+		d.AddString("abc")
+
+		now := sign.WithClock(func() time.Time { return moment.Add(10 * time.Second) })
+
+		assert.ErrorIs(t, d.Verify((*sign.RSAVerifier)(&key.PublicKey), sig, now, sign.WithLeeway(time.Second)),
+			sign.ErrSignatureExpired)
+		assert.NoError(t, d.Verify((*sign.RSAVerifier)(&key.PublicKey), sig, now, sign.WithLeeway(time.Minute)))
+	})
+
+	t.Run("zero timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		d := sign.NewDigest(sign.V4, time.Unix(0, 0))
+		d.AddString("abc")
+
+		zeroSig := mustOK(d.Sign((*sign.RSASigner)(key)))
+		verifier := (*sign.RSAVerifier)(&key.PublicKey)
+		opts := []sign.Option{
+			sign.WithClock(func() time.Time { return moment }),
+			sign.WithLeeway(time.Second),
+			sign.WithReplayGuard(sign.NewMemoryReplayGuard(10)),
+		}
+
+		// replay guard would forget the signature after the leeway, so it must
+		// not be accepted at all.
+		assert.ErrorIs(t, d.Verify(verifier, zeroSig, opts...), sign.ErrSignatureExpired)
+		assert.NoError(t, d.Verify(verifier, zeroSig, append(opts, sign.WithZeroTimestamps())...))
+	})
+}
+
 func createSign(moment time.Time) sign.Signature {
 	s := sign.NewDigest(sign.V4, moment)
 
@@ -0,0 +1,255 @@
+package sign_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/http/sign"
+)
+
+func TestDigest_AddReader(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Unix(1_000_000, 0)
+
+	buffered := sign.NewDigest(sign.V4, ts)
+	buffered.AddString("payload")
+
+	streamed := sign.NewDigest(sign.V4, ts)
+	n, err := streamed.AddReader(strings.NewReader("payload"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+
+	written := sign.NewDigest(sign.V4, ts)
+	_, err = io.WriteString(written, "pay")
+	require.NoError(t, err)
+	_, err = io.WriteString(written, "load")
+	require.NoError(t, err)
+
+	assert.Equal(t, buffered.Sum(nil), streamed.Sum(nil))
+	assert.Equal(t, buffered.Sum(nil), written.Sum(nil))
+}
+
+func TestStreaming(t *testing.T) {
+	t.Parallel()
+
+	pub, priv := mustOK2(ed25519.GenerateKey(rand.Reader))
+	signer := (*sign.Ed25519Signer)(&priv)
+	verifier := (*sign.Ed25519Verifier)(&pub)
+
+	moment := time.Unix(1_000_000, 0)
+	clock := sign.WithClock(func() time.Time { return moment })
+
+	payload := strings.Repeat("payload", 1000)
+
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			sign.DefaultErrorHandler(w, r, err)
+			return
+		}
+
+		_, _ = w.Write(body)
+	})
+
+	newRequest := func(body string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "http://example.com/path", strings.NewReader(body))
+	}
+
+	tests := []struct {
+		name     string
+		request  func(t *testing.T) *http.Request
+		opts     []sign.Option
+		wantCode int
+	}{
+		{
+			name: "streaming",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest(payload)
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithStreaming()},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "streaming without body",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := httptest.NewRequest(http.MethodGet, "http://example.com/path", nil)
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithStreaming()},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "streaming tampered body fails at EOF",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest(payload)
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+				r.Body = io.NopCloser(strings.NewReader(payload + "!"))
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithStreaming()},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "streaming expired fails upfront",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest(payload)
+				signRequest(t, r, sign.V4, moment.Add(-time.Hour), signer, sign.DefaultHeader)
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithStreaming()},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "buffered body too large",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest(payload)
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithMaxBodySize(100)},
+			wantCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "buffered body too large without content length",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest(payload)
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+				r.ContentLength = -1
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithMaxBodySize(100)},
+			wantCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "streaming body too large",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest(payload)
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithStreaming(), sign.WithMaxBodySize(100)},
+			wantCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "streaming body too large without content length",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest(payload)
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+				r.ContentLength = -1
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithStreaming(), sign.WithMaxBodySize(100)},
+			wantCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "body within limit",
+			request: func(t *testing.T) *http.Request {
+				t.Helper()
+				r := newRequest(payload)
+				signRequest(t, r, sign.V4, moment, signer, sign.DefaultHeader)
+
+				return r
+			},
+			opts:     []sign.Option{sign.WithStreaming(), sign.WithMaxBodySize(int64(len(payload)))},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := tt.request(t)
+			w := httptest.NewRecorder()
+
+			sign.NewMiddleware(verifier, append(tt.opts, clock)...)(echo).ServeHTTP(w, r)
+
+			require.Equal(t, tt.wantCode, w.Code, w.Body.String())
+
+			if tt.wantCode == http.StatusOK && r.Method == http.MethodPost {
+				assert.Equal(t, payload, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTransport_Streaming(t *testing.T) {
+	t.Parallel()
+
+	pub, priv := mustOK2(ed25519.GenerateKey(rand.Reader))
+	signer := (*sign.Ed25519Signer)(&priv)
+	verifier := (*sign.Ed25519Verifier)(&pub)
+
+	payload := []byte(strings.Repeat("payload", 1000))
+
+	t.Run("signs body obtained via GetBody", func(t *testing.T) {
+		t.Parallel()
+
+		var sent []byte
+
+		base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			require.NoError(t, sign.VerifyRequest(r, verifier))
+
+			sent = mustOK(io.ReadAll(r.Body))
+
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})
+
+		req := mustOK(http.NewRequest(http.MethodPost, "http://example.com/path", bytes.NewReader(payload)))
+		resp := mustOK(sign.NewTransport(base, signer, sign.WithStreaming()).RoundTrip(req))
+		_ = resp.Body.Close()
+
+		assert.Equal(t, payload, sent)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		t.Parallel()
+
+		base := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+
+			return nil, nil //nolint:nilnil
+		})
+
+		for _, opts := range [][]sign.Option{
+			{sign.WithMaxBodySize(100)},
+			{sign.WithMaxBodySize(100), sign.WithStreaming()},
+		} {
+			req := mustOK(http.NewRequest(http.MethodPost, "http://example.com/path", bytes.NewReader(payload)))
+			req.ContentLength = -1
+
+			_, err := sign.NewTransport(base, signer, opts...).RoundTrip(req)
+			require.ErrorIs(t, err, sign.ErrBodyTooLarge)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package sign
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Transport is an [http.RoundTripper] that signs every outgoing request and
+// passes it to the underlying transport.
+//
+// The request passed to RoundTrip is never modified, the signature is set on its
+// clone. In case request has GetBody set, it is used to obtain the body for
+// signing, so the original one is left intact. In any case the request sent
+// to the underlying transport has GetBody set, therefore it can be retried.
+//
+// In streaming mode (see [WithStreaming]) the body obtained via GetBody is
+// hashed without buffering, and the body to send is obtained via GetBody once
+// again.
+type Transport struct {
+	base    http.RoundTripper
+	signer  Signer
+	keyring *Keyring
+	cfg     *config
+}
+
+// NewTransport creates a new [Transport] that signs requests with signer and
+// sends them using base. In case base is nil, [http.DefaultTransport] is used.
+//
+// Signature version, key ID, signed headers, clock and header name are
+// configured via [WithVersion], [WithKeyID], [WithSignedHeaders], [WithClock]
+// and [WithHeader] options. Panics if version is not supported, or it does not
+// support key ID or signed headers while they are set. RFC 9421 message
+// signatures are produced instead with [WithMessageSignatures] option.
+func NewTransport(base http.RoundTripper, signer Signer, opts ...Option) *Transport {
+	return newTransport(base, signer, nil, newConfig(opts))
+}
+
+// NewKeyringTransport creates a new [Transport] that signs requests with the
+// key picked from keyring by [Keyring.SigningKey] at the moment of signing,
+// embedding its ID into signature. Signatures are of V5 unless other version
+// is set with [WithVersion].
+func NewKeyringTransport(base http.RoundTripper, kr *Keyring, opts ...Option) *Transport {
+	return newTransport(base, nil, kr, newConfig(append([]Option{WithVersion(V5)}, opts...)))
+}
+
+func newTransport(base http.RoundTripper, signer Signer, kr *Keyring, cfg *config) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// message signatures are not versioned and support key ID and headers.
+	if !cfg.messages {
+		validateDigestParams(cfg.version, cfg.keyID, cfg.signedHeaders)
+
+		if kr != nil && cfg.version < V5 {
+			panic("key ID is not supported by signature version")
+		}
+	}
+
+	return &Transport{
+		base:    base,
+		signer:  signer,
+		keyring: kr,
+		cfg:     cfg,
+	}
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clone, err := t.sign(req)
+
+	// RoundTripper must always close the body, including on errors, and we're
+	// done reading it at this point anyway.
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(clone) //nolint:wrapcheck
+}
+
+func (t *Transport) sign(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	rewindable := req.Body != nil && req.Body != http.NoBody && req.GetBody != nil
+
+	if rewindable && !t.cfg.streaming {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("get request body failed: %w", err)
+		}
+
+		defer body.Close()
+
+		clone.Body = body
+	}
+
+	now := t.cfg.now()
+
+	signer, keyID, err := t.signingKey(now)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.cfg.messages {
+		if err = signMessage(clone, signer, keyID, now, t.cfg); err != nil {
+			return nil, err
+		}
+
+		return clone, nil
+	}
+
+	if err = t.signDigest(clone, signer, keyID, now, rewindable); err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// signingKey returns the signer and its key ID to sign the request with at the
+// moment now.
+func (t *Transport) signingKey(now time.Time) (Signer, string, error) {
+	if t.keyring == nil {
+		return t.signer, t.cfg.keyID, nil
+	}
+
+	key, err := t.keyring.SigningKey(now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key.Signer, key.ID, nil
+}
+
+// signDigest signs the request with the signature of configured version. In
+// streaming mode the body of rewindable request is read without buffering.
+func (t *Transport) signDigest(clone *http.Request, signer Signer, keyID string, now time.Time, rewindable bool) error {
+	digest := newDigest(t.cfg.version, now, keyID, t.cfg.signedHeaders)
+
+	if rewindable && t.cfg.streaming {
+		if err := t.addStreamedBody(digest, clone); err != nil {
+			return err
+		}
+	} else if err := digest.addRequest(clone, t.cfg.maxBodySize); err != nil {
+		return fmt.Errorf("build request digest failed: %w", err)
+	}
+
+	sig, err := digest.Sign(signer)
+	if err != nil {
+		return err
+	}
+
+	clone.Header.Set(t.cfg.header, sig.HexString())
+
+	return nil
+}
+
+// addStreamedBody adds the request to the digest reading the body obtained via
+// GetBody without buffering it, then sets a fresh body to be sent.
+func (t *Transport) addStreamedBody(digest *Digest, clone *http.Request) error {
+	if err := digest.addRequestHead(clone); err != nil {
+		return fmt.Errorf("build request digest failed: %w", err)
+	}
+
+	return addGetBody(digest, clone, t.cfg.maxBodySize)
+}
+
+// addGetBody adds the body obtained via GetBody to the digest without buffering
+// it, then sets a fresh body of the request.
+func addGetBody(digest *Digest, r *http.Request, maxBodySize int64) error {
+	if maxBodySize > 0 && r.ContentLength > maxBodySize {
+		return ErrBodyTooLarge
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return fmt.Errorf("get request body failed: %w", err)
+	}
+
+	err = digest.addBody(body, maxBodySize)
+	_ = body.Close()
+
+	if err != nil {
+		return fmt.Errorf("build request digest failed: %w", err)
+	}
+
+	if r.Body, err = r.GetBody(); err != nil {
+		return fmt.Errorf("get request body failed: %w", err)
+	}
+
+	return nil
+}
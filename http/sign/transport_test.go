@@ -0,0 +1,123 @@
+package sign_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"dev.gaijin.team/go/golib/http/sign"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	key := mustOK(rsa.GenerateKey(rand.Reader, 2048))
+	signer := (*sign.RSASigner)(key)
+	verifier := (*sign.RSAVerifier)(&key.PublicKey)
+
+	moment := time.Unix(1_000_000, 0)
+	clock := sign.WithClock(func() time.Time { return moment })
+
+	t.Run("signed requests pass middleware", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(sign.NewMiddleware(verifier, clock)(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(w, r.Body)
+			}),
+		))
+		t.Cleanup(srv.Close)
+
+		for _, v := range []sign.Version{sign.V2, sign.V3, sign.V4} {
+			client := &http.Client{Transport: sign.NewTransport(srv.Client().Transport, signer, clock, sign.WithVersion(v))}
+
+			for _, body := range []io.Reader{nil, strings.NewReader("payload")} {
+				req := mustOK(http.NewRequest(http.MethodPost, srv.URL+"/path?q=1", body)) //nolint:noctx
+
+				resp := mustOK(client.Do(req))
+				respBody := mustOK(io.ReadAll(resp.Body))
+				_ = resp.Body.Close()
+
+				require.Equal(t, http.StatusOK, resp.StatusCode, "version %d", v)
+
+				if body != nil {
+					assert.Equal(t, "payload", string(respBody))
+				}
+
+				assert.Empty(t, req.Header.Get(sign.DefaultHeader), "original request must not be modified")
+			}
+		}
+	})
+
+	t.Run("clone with GetBody", func(t *testing.T) {
+		t.Parallel()
+
+		var sent *http.Request
+
+		base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			sent = r
+
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil //nolint:exhaustruct
+		})
+
+		body := bytes.NewReader([]byte("payload"))
+		req := mustOK(http.NewRequest(http.MethodPut, "http://example.com/", body)) //nolint:noctx
+
+		tr := sign.NewTransport(base, signer, clock, sign.WithHeader("X-Custom"))
+		resp := mustOK(tr.RoundTrip(req))
+		_ = resp.Body.Close()
+
+		require.NotNil(t, sent)
+		assert.NotSame(t, req, sent)
+		assert.NotEmpty(t, sent.Header.Get("X-Custom"))
+		assert.Empty(t, req.Header.Get("X-Custom"))
+
+		// body passed downstream and the one obtained via GetBody (used for
+		// retries) are the same as the signed one.
+		assert.Equal(t, "payload", string(mustOK(io.ReadAll(sent.Body))))
+		require.NotNil(t, sent.GetBody)
+		assert.Equal(t, "payload", string(mustOK(io.ReadAll(mustOK(sent.GetBody())))))
+
+		// original request GetBody still yields the full body.
+		assert.Equal(t, "payload", string(mustOK(io.ReadAll(mustOK(req.GetBody())))))
+
+		sent.Header.Set(sign.DefaultHeader, sent.Header.Get("X-Custom"))
+		sent.Body = mustOK(sent.GetBody())
+
+		assert.NoError(t, sign.VerifyRequest(sent, verifier, clock))
+	})
+
+	t.Run("body read error", func(t *testing.T) {
+		t.Parallel()
+
+		base := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+
+			return nil, nil //nolint:nilnil
+		})
+
+		req := mustOK(http.NewRequest(http.MethodPut, "http://example.com/", errReader{})) //nolint:noctx
+
+		_, err := sign.NewTransport(base, signer).RoundTrip(req) //nolint:bodyclose
+		assert.ErrorIs(t, err, errRead)
+	})
+
+	assert.Panics(t, func() {
+		sign.NewTransport(nil, signer, sign.WithVersion(sign.VErr))
+	})
+}
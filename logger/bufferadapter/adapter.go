@@ -76,6 +76,8 @@ func New() (*Adapter, *LogEntries) {
 	return &Adapter{buff: buff}, buff
 }
 
+// Log records the entry. Values of lazy fields are computed at this moment, so
+// the entry holds the values captured at the time of logging.
 func (a *Adapter) Log(level int, msg string, fs ...fields.Field) {
 	e := LogEntry{
 		Level:  level,
@@ -83,6 +85,10 @@ func (a *Adapter) Log(level int, msg string, fs ...fields.Field) {
 		Fields: append(slices.Clone(a.fs), fs...),
 	}
 
+	for i, f := range e.Fields {
+		e.Fields[i] = f.Resolve()
+	}
+
 	a.buff.Add(e)
 }
 
@@ -75,6 +75,26 @@ func TestAdapter(t *testing.T) {
 			Fields: fields.List{fields.F("foo", "bar"), fields.F("baz", "qux")},
 		}, buff.Get(1))
 	})
+
+	t.Run("lazy fields", func(t *testing.T) {
+		t.Parallel()
+
+		adapterSrc, buff := bufferadapter.New()
+
+		state := "initial"
+		value := func() any { return state }
+
+		adapter := adapterSrc.WithFields(fields.Lazy("parent", value))
+		adapter.Log(42, "foo", fields.Lazy("own", value))
+
+		state = "changed"
+
+		assert.Equal(t, bufferadapter.LogEntry{
+			Level:  42,
+			Msg:    "foo",
+			Fields: fields.List{fields.F("parent", "initial"), fields.F("own", "initial")},
+		}, buff.Get(0))
+	})
 }
 
 func TestLogEntries(t *testing.T) {
@@ -185,7 +185,8 @@ func DefaultStackTraceMapper(st *stacktrace.Stack) fields.Field {
 // WithStackTraceMapper sets a custom stack trace mapper for the logger.
 //
 // The stack trace mapper controls how stack traces (captured via
-// [Logger.WithStackTrace]) are converted to fields. By default,
+// [Logger.WithStackTrace] or carried by logged [e.Err] errors) are converted
+// to fields. By default,
 // [DefaultStackTraceMapper] is used, which creates fields with the key
 // "stacktrace" and formats the stack as a string.
 //
@@ -386,7 +387,9 @@ func (l Logger) TraceE(msg string, err error, fs ...fields.Field) {
 //
 // If the level is higher than the logger's maximum level (set via [WithLevel]),
 // the message is not logged. If err is not nil, it is converted to a field using
-// the error mapper and appended to the provided fields.
+// the error mapper and appended to the provided fields. In case err chain carries
+// a stack trace captured by [e.Err] (see [e.StackOf]), it is appended as well
+// using the stack trace mapper.
 //
 // For no-op loggers, this method returns immediately without any operation.
 func (l Logger) Log(level int, msg string, err error, fs ...fields.Field) {
@@ -416,6 +419,10 @@ func (l Logger) log(level int, msg string, err error, fs ...fields.Field) {
 
 	if err != nil {
 		fs = append(fs, l.mappers.error(err))
+
+		if st := e.StackOf(err); st != nil {
+			fs = append(fs, l.mappers.stackTrace(st))
+		}
 	}
 
 	l.adapter.Log(level, msg, fs...)
@@ -40,11 +40,15 @@ func DefaultLogLevelMapper(level int) logrus.Level {
 
 // Adapter of logrus logger for [logger.Logger].
 //
-// This adapter guarantees support of stock logger's levels.
+// This adapter guarantees support of stock logger's levels. Since logrus does
+// not support nested fields, keys of fields of groups and namespaces are
+// prefixed with their keys separated by dot.
 type Adapter struct {
 	lgr *logrus.Entry
 
 	lvlMapper LogLevelMapper `exhaustruct:"optional"`
+	// prefix of keys of fields namespaced by fields.Namespace.
+	prefix string `exhaustruct:"optional"`
 }
 
 // New creates new logging adapter using provided [logrus.Entry].
@@ -66,14 +70,27 @@ func New(lgr *logrus.Entry, opts ...Option) *Adapter {
 
 // Log implements [logger.Adapter.Log].
 func (a *Adapter) Log(level int, msg string, fs ...fields.Field) {
-	a.lgr.WithFields(fieldsListToLogrusFields(fs)).Log(a.lvlMapper(level), msg)
+	lvl := a.lvlMapper(level)
+
+	// fields are only converted for entries being written, so that values of lazy
+	// fields are not computed in vain.
+	if !a.lgr.Logger.IsLevelEnabled(lvl) {
+		return
+	}
+
+	lfs, _ := fieldsListToLogrusFields(a.prefix, fs)
+
+	a.lgr.WithFields(lfs).Log(lvl, msg)
 }
 
 // WithFields implements [logger.Adapter.WithFields].
 func (a *Adapter) WithFields(fs ...fields.Field) logger.Adapter {
+	lfs, prefix := fieldsListToLogrusFields(a.prefix, fs)
+
 	return &Adapter{
-		lgr:       a.lgr.WithFields(fieldsListToLogrusFields(fs)),
+		lgr:       a.lgr.WithFields(lfs),
 		lvlMapper: a.lvlMapper,
+		prefix:    prefix,
 	}
 }
 
@@ -82,12 +99,24 @@ func (*Adapter) Flush() error {
 	return nil
 }
 
-func fieldsListToLogrusFields(fs fields.List) logrus.Fields {
+// fieldsListToLogrusFields converts fields into logrus ones, prefixing their
+// keys with prefix. Returns the prefix extended with namespaces found in fs,
+// which applies to fields following them.
+func fieldsListToLogrusFields(prefix string, fs fields.List) (logrus.Fields, string) {
 	lfs := make(logrus.Fields, len(fs))
 
 	for _, f := range fs {
-		lfs[f.K] = f.V
+		switch f.Type() { //nolint:exhaustive
+		case fields.TypeNamespace:
+			prefix += f.K + "."
+		case fields.TypeGroup:
+			for _, gf := range f.GroupValue().Flatten() {
+				lfs[prefix+f.K+"."+gf.K] = gf.Value()
+			}
+		default:
+			lfs[prefix+f.K] = f.Value()
+		}
 	}
 
-	return lfs
+	return lfs, prefix
 }
@@ -95,6 +95,79 @@ func TestAdapter(t *testing.T) {
 		assert.Nil(t, hook[0].Data[logrus.ErrorKey])
 	})
 
+	t.Run(".Log() with sensitive field", func(t *testing.T) {
+		t.Parallel()
+
+		hook := logrusHook{}
+		adapter := newAdapter(&hook)
+
+		adapter.Log(logger.LevelInfo, "test", fields.Sensitive("password", "qwerty"))
+
+		require.Len(t, hook, 1)
+
+		formatters := []logrus.Formatter{&logrus.TextFormatter{}, &logrus.JSONFormatter{}} //nolint:exhaustruct
+
+		for _, formatter := range formatters {
+			out, err := formatter.Format(hook[0])
+			require.NoError(t, err)
+			assert.Contains(t, string(out), fields.RedactedValue)
+			assert.NotContains(t, string(out), "qwerty")
+		}
+	})
+
+	t.Run(".Log() with lazy field", func(t *testing.T) {
+		t.Parallel()
+
+		hook := logrusHook{}
+
+		ll := logrus.New()
+		ll.Level = logrus.InfoLevel
+		ll.AddHook(&hook)
+		ll.SetOutput(&discardingWriter{})
+
+		adapter := logrusadapter.New(logrus.NewEntry(ll))
+
+		calls := 0
+		lazy := fields.Lazy("dump", func() any {
+			calls++
+
+			return "value"
+		})
+
+		adapter.Log(logger.LevelDebug, "filtered", lazy)
+		assert.Zero(t, calls)
+
+		adapter.Log(logger.LevelInfo, "written", lazy)
+		assert.Equal(t, 1, calls)
+		require.Len(t, hook, 1)
+		assert.Equal(t, "value", hook[0].Data["dump"])
+	})
+
+	t.Run("groups and namespaces", func(t *testing.T) {
+		t.Parallel()
+
+		hook := logrusHook{}
+		adapter := newAdapter(&hook).
+			WithFields(fields.F("service", "api"), fields.Namespace("ctx"), fields.F("trace", "t1"))
+
+		adapter.Log(logger.LevelInfo, "test",
+			fields.F("id", 1),
+			fields.Group("request", fields.F("method", "GET"), fields.Namespace("inner"), fields.F("size", 2)),
+			fields.Namespace("tail"),
+			fields.F("last", true),
+		)
+
+		require.Len(t, hook, 1)
+		assert.Equal(t, logrus.Fields{
+			"service":                "api",
+			"ctx.trace":              "t1",
+			"ctx.id":                 1,
+			"ctx.request.method":     "GET",
+			"ctx.request.inner.size": 2,
+			"ctx.tail.last":          true,
+		}, hook[0].Data)
+	})
+
 	t.Run(".WithFields()", func(t *testing.T) {
 		t.Parallel()
 
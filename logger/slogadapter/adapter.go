@@ -68,12 +68,19 @@ func New(lgr *slog.Logger, opts ...Option) *Adapter {
 }
 
 func (a *Adapter) Log(level int, msg string, fs ...fields.Field) {
-	a.lgr.LogAttrs(context.Background(), a.lvlMapper(level), msg, fieldsListToSlogAttrs(fs)...)
+	lvl := a.lvlMapper(level)
+
+	// fields are only converted for entries being written.
+	if !a.lgr.Enabled(context.Background(), lvl) {
+		return
+	}
+
+	a.lgr.LogAttrs(context.Background(), lvl, msg, fields.List(fs).Attrs()...)
 }
 
 func (a *Adapter) WithFields(fs ...fields.Field) logger.Adapter {
 	return &Adapter{
-		lgr:       slog.New(a.lgr.Handler().WithAttrs(fieldsListToSlogAttrs(fs))),
+		lgr:       slog.New(withFields(a.lgr.Handler(), fs)),
 		lvlMapper: a.lvlMapper,
 	}
 }
@@ -82,12 +89,14 @@ func (*Adapter) Flush() error {
 	return nil
 }
 
-func fieldsListToSlogAttrs(fs fields.List) []slog.Attr {
-	zfs := make([]slog.Attr, 0, len(fs))
-
-	for _, f := range fs {
-		zfs = append(zfs, slog.Any(f.K, f.V))
+// withFields returns the handler with fields attached, turning namespaces into
+// handler groups, so that they apply to all attributes added later.
+func withFields(h slog.Handler, fs fields.List) slog.Handler {
+	for i, f := range fs {
+		if f.Type() == fields.TypeNamespace {
+			return withFields(h.WithAttrs(fs[:i].Attrs()).WithGroup(f.K), fs[i+1:])
+		}
 	}
 
-	return zfs
+	return h.WithAttrs(fs.Attrs())
 }
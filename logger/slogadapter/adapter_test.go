@@ -2,11 +2,13 @@
 package slogadapter_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"log/slog"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,6 +135,74 @@ func TestAdapter(t *testing.T) {
 		assert.Nil(t, buf[0].Attrs[errorKey])
 	})
 
+	t.Run(".Log() with typed fields", func(t *testing.T) {
+		t.Parallel()
+
+		buf := entriesBuffer{}
+		adapter := newAdapter(&buf)
+
+		ts := time.Unix(1700000000, 0).UTC()
+
+		adapter.Log(logger.LevelInfo, "typed",
+			fields.String("string", "value"),
+			fields.Int("int", 42),
+			fields.Uint64("uint", 7),
+			fields.Float64("float", 1.5),
+			fields.Bool("bool", true),
+			fields.Duration("duration", time.Second),
+			fields.Time("time", ts),
+		)
+
+		require.Len(t, buf, 1)
+		assert.Equal(t, map[string]any{
+			"string":   "value",
+			"int":      int64(42),
+			"uint":     uint64(7),
+			"float":    1.5,
+			"bool":     true,
+			"duration": time.Second,
+			"time":     ts,
+		}, buf[0].Attrs)
+	})
+
+	t.Run(".Log() with lazy field", func(t *testing.T) {
+		t.Parallel()
+
+		b := &bytes.Buffer{}
+		handler := slog.NewTextHandler(b, &slog.HandlerOptions{Level: slog.LevelInfo}) //nolint:exhaustruct
+		adapter := slogadapter.New(slog.New(handler))
+
+		calls := 0
+		lazy := fields.Lazy("dump", func() any {
+			calls++
+
+			return "value"
+		})
+
+		adapter.Log(logger.LevelDebug, "filtered", lazy)
+		assert.Zero(t, calls)
+
+		adapter.Log(logger.LevelInfo, "written", lazy)
+		assert.Equal(t, 1, calls)
+		assert.Contains(t, b.String(), "dump=value")
+	})
+
+	t.Run("groups and namespaces", func(t *testing.T) {
+		t.Parallel()
+
+		b := &bytes.Buffer{}
+		adapter := slogadapter.New(slog.New(slog.NewJSONHandler(b, nil))).
+			WithFields(fields.F("service", "api"), fields.Namespace("ctx"), fields.F("trace", "t1"))
+
+		adapter.Log(logger.LevelInfo, "test",
+			fields.F("id", 1),
+			fields.Group("request", fields.F("method", "GET"), fields.Namespace("inner"), fields.F("size", 2)),
+		)
+
+		assert.Contains(t, b.String(),
+			`"service":"api","ctx":{"trace":"t1","id":1,"request":{"method":"GET","inner":{"size":2}}}}`)
+	})
+
 	t.Run(".WithFields()", func(t *testing.T) {
 		t.Parallel()
 
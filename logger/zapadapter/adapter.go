@@ -88,8 +88,62 @@ func fieldsListToZapFields(fs fields.List) []zap.Field {
 	zfs := make([]zap.Field, 0, len(fs))
 
 	for _, f := range fs {
-		zfs = append(zfs, zap.Any(f.K, f.V))
+		zfs = append(zfs, fieldToZapField(f))
 	}
 
 	return zfs
 }
+
+// fieldToZapField converts typed fields into native zap fields, avoiding
+// reflection. Groups are converted into zap objects and namespaces into zap
+// namespaces.
+func fieldToZapField(f fields.Field) zap.Field {
+	switch f.Type() {
+	case fields.TypeError:
+		return zap.NamedError(f.K, f.ErrorValue())
+	case fields.TypeLazy:
+		return zap.Any(f.K, f.Value())
+	case fields.TypeGroup:
+		return zap.Object(f.K, groupMarshaler(f.GroupValue()))
+	case fields.TypeNamespace:
+		return zap.Namespace(f.K)
+	case fields.TypeAny:
+		return zap.Any(f.K, f.V)
+	default:
+		return scalarToZapField(f)
+	}
+}
+
+// scalarToZapField converts the field of scalar type to zap.Field without
+// boxing its value.
+func scalarToZapField(f fields.Field) zap.Field {
+	switch f.Type() { //nolint:exhaustive
+	case fields.TypeString:
+		return zap.String(f.K, f.StringValue())
+	case fields.TypeInt, fields.TypeInt64:
+		return zap.Int64(f.K, f.Int64Value())
+	case fields.TypeUint, fields.TypeUint64:
+		return zap.Uint64(f.K, f.Uint64Value())
+	case fields.TypeFloat64:
+		return zap.Float64(f.K, f.Float64Value())
+	case fields.TypeBool:
+		return zap.Bool(f.K, f.BoolValue())
+	case fields.TypeDuration:
+		return zap.Duration(f.K, f.DurationValue())
+	case fields.TypeTime:
+		return zap.Time(f.K, f.TimeValue())
+	default:
+		return zap.Any(f.K, f.V)
+	}
+}
+
+// groupMarshaler encodes fields of a group as zap object.
+func groupMarshaler(fs fields.List) zapcore.ObjectMarshalerFunc {
+	return func(enc zapcore.ObjectEncoder) error {
+		for _, f := range fs {
+			fieldToZapField(f).AddTo(enc)
+		}
+
+		return nil
+	}
+}
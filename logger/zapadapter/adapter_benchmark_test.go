@@ -0,0 +1,62 @@
+package zapadapter_test
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"dev.gaijin.team/go/golib/fields"
+	"dev.gaijin.team/go/golib/logger"
+	"dev.gaijin.team/go/golib/logger/zapadapter"
+)
+
+// goos: linux
+// goarch: amd64
+// pkg: dev.gaijin.team/go/golib/logger/zapadapter
+// cpu: Intel(R) Xeon(R) Processor
+// Benchmark_Log/any_fields         	  584024	      1959 ns/op	     432 B/op	       5 allocs/op
+// Benchmark_Log/typed_fields       	  809616	      1866 ns/op	     384 B/op	       1 allocs/op
+// PASS.
+//
+// The only allocation left for typed fields is the slice of zap fields.
+func Benchmark_Log(b *testing.B) {
+	adapter := zapadapter.New(zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		&discardingWriter{},
+		zapcore.DebugLevel,
+	)))
+
+	ts := time.Now()
+
+	b.Run("any fields", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := range b.N {
+			adapter.Log(logger.LevelInfo, "message",
+				fields.F("string", "value"),
+				fields.F("int", i+256),
+				fields.F("float", float64(i)+0.5),
+				fields.F("bool", true),
+				fields.F("duration", time.Duration(i)),
+				fields.F("time", ts),
+			)
+		}
+	})
+
+	b.Run("typed fields", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := range b.N {
+			adapter.Log(logger.LevelInfo, "message",
+				fields.String("string", "value"),
+				fields.Int("int", i+256),
+				fields.Float64("float", float64(i)+0.5),
+				fields.Bool("bool", true),
+				fields.Duration("duration", time.Duration(i)),
+				fields.Time("time", ts),
+			)
+		}
+	})
+}
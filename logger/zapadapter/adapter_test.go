@@ -4,6 +4,7 @@ package zapadapter_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,6 +39,15 @@ func zapLogger() (*zap.Logger, *observer.ObservedLogs) {
 	return zap.New(zapcore.NewTee(discardingCore, testCore)), logs
 }
 
+func fieldTypes(zfs []zap.Field) []zapcore.FieldType {
+	types := make([]zapcore.FieldType, 0, len(zfs))
+	for _, f := range zfs {
+		types = append(types, f.Type)
+	}
+
+	return types
+}
+
 func newAdapter(opts ...zapadapter.Option) (logger.Adapter, *observer.ObservedLogs) {
 	lgr, logs := zapLogger()
 
@@ -91,6 +101,101 @@ func TestAdapter(t *testing.T) {
 		assert.Nil(t, logs.All()[0].ContextMap()["error"])
 	})
 
+	t.Run(".Log() with sensitive field", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, logs := newAdapter()
+
+		adapter.Log(logger.LevelInfo, "test", fields.Sensitive("password", "qwerty"))
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, fields.RedactedValue, logs.All()[0].ContextMap()["password"])
+	})
+
+	t.Run(".Log() with typed fields", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, logs := newAdapter()
+
+		errTest := errors.New("test")
+		ts := time.Unix(1700000000, 0).UTC()
+
+		adapter.Log(logger.LevelInfo, "typed",
+			fields.String("string", "value"),
+			fields.Int("int", 42),
+			fields.Uint64("uint", 7),
+			fields.Float64("float", 1.5),
+			fields.Bool("bool", true),
+			fields.Duration("duration", time.Second),
+			fields.Time("time", ts),
+			fields.Err("err", errTest),
+		)
+
+		require.Equal(t, 1, logs.Len())
+
+		entry := logs.All()[0]
+		assert.Equal(t, []zapcore.FieldType{
+			zapcore.StringType, zapcore.Int64Type, zapcore.Uint64Type, zapcore.Float64Type,
+			zapcore.BoolType, zapcore.DurationType, zapcore.TimeType, zapcore.ErrorType,
+		}, fieldTypes(entry.Context))
+		assert.Equal(t, map[string]any{
+			"string":   "value",
+			"int":      int64(42),
+			"uint":     uint64(7),
+			"float":    1.5,
+			"bool":     true,
+			"duration": time.Second,
+			"time":     ts,
+			"err":      "test",
+		}, entry.ContextMap())
+	})
+
+	t.Run(".Log() with lazy field", func(t *testing.T) {
+		t.Parallel()
+
+		core, logs := observer.New(zapcore.InfoLevel)
+		adapter := zapadapter.New(zap.New(core))
+
+		calls := 0
+		lazy := fields.Lazy("dump", func() any {
+			calls++
+
+			return "value"
+		})
+
+		adapter.Log(logger.LevelDebug, "filtered", lazy)
+		assert.Zero(t, calls)
+
+		adapter.Log(logger.LevelInfo, "written", lazy)
+		assert.Equal(t, 1, calls)
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, "value", logs.All()[0].ContextMap()["dump"])
+	})
+
+	t.Run("groups and namespaces", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, logs := newAdapter()
+
+		adapter = adapter.WithFields(fields.F("service", "api"), fields.Namespace("ctx"))
+		adapter.Log(logger.LevelInfo, "test",
+			fields.F("id", 1),
+			fields.Group("request", fields.F("method", "GET"), fields.Namespace("inner"), fields.F("size", 2)),
+		)
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, map[string]any{
+			"service": "api",
+			"ctx": map[string]any{
+				"id": int64(1),
+				"request": map[string]any{
+					"method": "GET",
+					"inner":  map[string]any{"size": int64(2)},
+				},
+			},
+		}, logs.All()[0].ContextMap())
+	})
+
 	t.Run(".WithFields()", func(t *testing.T) {
 		t.Parallel()
 
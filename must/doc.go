@@ -25,6 +25,9 @@
 //   - must.True[T](value T, condition bool) T - returns value if condition is true, panics otherwise
 //   - must.NoErr(err error) - panics if err is not nil
 //
+// Panics raised by must functions carry *e.Err, which can be converted back
+// into an ordinary error with e.Recover or e.Safe.
+//
 // # Examples
 //
 //	// Static data parsing